@@ -66,6 +66,7 @@ func TestSearchAnnotation(t *testing.T) {
 	want.Scores = ScoreMap{
 		returned.Annotations[0]: 100,
 	}
+	want.Results = []Scored[*Annotation]{{Entity: returned.Annotations[0], Score: 100}}
 
 	if !reflect.DeepEqual(*returned, want) {
 		t.Error(requestDiff(&want, returned))
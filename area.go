@@ -25,26 +25,49 @@
 
 package gomusicbrainz
 
-import "encoding/xml"
+import (
+	"context"
+	"encoding/xml"
+)
 
 // Area represents a geographic region or settlement.
 type Area struct {
 	ID            MBID           `xml:"id,attr"`
-	Type          string         `xml:"type,attr"`
-	Name          string         `xml:"name"`
-	SortName      string         `xml:"sort-name"`
+	Type          AreaType       `xml:"type,attr,omitempty"`
+	TypeID        MBID           `xml:"type-id,attr,omitempty"`
+	Name          string         `xml:"name,omitempty"`
+	SortName      string         `xml:"sort-name,omitempty"`
+	ISO31661Codes []string       `xml:"iso-3166-1-code-list>iso-3166-1-code"`
 	ISO31662Codes []ISO31662Code `xml:"iso-3166-2-code-list>iso-3166-2-code"`
 	Lifespan      Lifespan       `xml:"life-span"`
 	Aliases       []Alias        `xml:"alias-list>alias"`
 }
 
-func (mbe *Area) lookupResult() interface{} {
-	var res struct {
-		XMLName xml.Name `xml:"metadata"`
-		Ptr     *Area    `xml:"area"`
+// MarshalXML omits empty areas and empty code and alias lists.
+func (mbe Area) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+
+	if isZero(mbe) {
+		return nil
+	}
+
+	type area Area
+	res := struct {
+		*area
+		ISO31661Codes *xmlList[string]       `xml:"iso-3166-1-code-list"`
+		ISO31662Codes *xmlList[ISO31662Code] `xml:"iso-3166-2-code-list"`
+		Aliases       *xmlList[Alias]        `xml:"alias-list"`
+	}{
+		area:          (*area)(&mbe),
+		ISO31661Codes: newXMLList("iso-3166-1-code", mbe.ISO31661Codes),
+		ISO31662Codes: newXMLList("iso-3166-2-code", mbe.ISO31662Codes),
+		Aliases:       newXMLList("alias", mbe.Aliases),
 	}
-	res.Ptr = mbe
-	return &res
+
+	return e.EncodeElement(res, start)
+}
+
+func (mbe *Area) lookupResult() interface{} {
+	return &lookupDoc{mbe}
 }
 
 func (mbe *Area) apiEndpoint() string {
@@ -86,45 +109,25 @@ func (c *WS2Client) LookupArea(id MBID, inc ...string) (*Area, error) {
 // http://musicbrainz.org/doc/Development/XML_Web_Service/Version_2/Search#Area
 func (c *WS2Client) SearchArea(searchTerm string, limit, offset int) (*AreaSearchResponse, error) {
 
-	result := areaListResult{}
-	err := c.searchRequest("/area", &result, searchTerm, limit, offset)
-
-	rsp := AreaSearchResponse{}
-	rsp.WS2ListResponse = result.AreaList.WS2ListResponse
-	rsp.Scores = make(ScoreMap)
-
-	for i, v := range result.AreaList.Areas {
-		rsp.Areas = append(rsp.Areas, v.Area)
-		rsp.Scores[rsp.Areas[i]] = v.Score
-	}
+	result, err := Search[*Area](context.Background(), c, searchTerm, limit, offset)
 
-	return &rsp, err
+	return &AreaSearchResponse{
+		WS2ListResponse: result.WS2ListResponse,
+		Areas:           Entities(result.Results),
+		Scores:          scoreMap(result.Results),
+		Results:         result.Results,
+	}, err
 }
 
 // AreaSearchResponse is the response type returned by the SearchArea method.
 type AreaSearchResponse struct {
 	WS2ListResponse
-	Areas  []*Area
-	Scores ScoreMap
+	Areas   []*Area
+	Scores  ScoreMap
+	Results []Scored[*Area]
 }
 
 // ResultsWithScore returns a slice of Areas with a min score.
 func (r *AreaSearchResponse) ResultsWithScore(score int) []*Area {
-	var res []*Area
-	for _, v := range r.Areas {
-		if r.Scores[v] >= score {
-			res = append(res, v)
-		}
-	}
-	return res
-}
-
-type areaListResult struct {
-	AreaList struct {
-		WS2ListResponse
-		Areas []struct {
-			*Area
-			Score int `xml:"http://musicbrainz.org/ns/ext#-2.0 score,attr"`
-		} `xml:"area"`
-	} `xml:"area-list"`
+	return Entities(FilterByScore(r.Results, score))
 }
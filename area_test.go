@@ -69,6 +69,7 @@ func TestSearchArea(t *testing.T) {
 	want.Scores = ScoreMap{
 		returned.Areas[0]: 100,
 	}
+	want.Results = []Scored[*Area]{{Entity: returned.Areas[0], Score: 100}}
 
 	if !reflect.DeepEqual(*returned, want) {
 		t.Error(requestDiff(&want, returned))
@@ -25,33 +25,79 @@
 
 package gomusicbrainz
 
-import "encoding/xml"
+import (
+	"context"
+	"encoding/xml"
+)
 
 // Artist represents generally a musician, a group of musicians, a collaboration
 // of multiple musicians or other music professionals.
 type Artist struct {
 	ID             MBID               `xml:"id,attr"`
-	Type           string             `xml:"type,attr"`
-	Name           string             `xml:"name"`
-	Disambiguation string             `xml:"disambiguation"`
-	SortName       string             `xml:"sort-name"`
-	CountryCode    string             `xml:"country"`
-	Gender         string             `xml:"gender"`
+	Type           ArtistType         `xml:"type,attr,omitempty"`
+	TypeID         MBID               `xml:"type-id,attr,omitempty"`
+	Name           string             `xml:"name,omitempty"`
+	Disambiguation string             `xml:"disambiguation,omitempty"`
+	SortName       string             `xml:"sort-name,omitempty"`
+	CountryCode    string             `xml:"country,omitempty"`
+	Gender         Gender             `xml:"gender,omitempty"`
+	GenderID       MBID               `xml:"-"`
 	Lifespan       Lifespan           `xml:"life-span"`
 	Area           Area               `xml:"area"`
 	BeginArea      Area               `xml:"begin-area"`
 	Aliases        []*Alias           `xml:"alias-list>alias"`
 	Tags           []Tag              `xml:"tag-list>tag"`
+	Rating         Rating             `xml:"rating"`
+	UserRating     int                `xml:"user-rating,omitempty"`
 	Relations      TargetRelationsMap `xml:"relation-list"`
 }
 
-func (mbe *Artist) lookupResult() interface{} {
+// UnmarshalXML is needed to implement XMLUnmarshaler for artists to decode the
+// MBID of the gender element.
+func (mbe *Artist) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+
+	type artist Artist
 	var res struct {
-		XMLName xml.Name `xml:"metadata"`
-		Ptr     *Artist  `xml:"artist"`
+		*artist
+		Gender idValue `xml:"gender"`
+	}
+	res.artist = (*artist)(mbe)
+
+	if err := d.DecodeElement(&res, &start); err != nil {
+		return err
+	}
+
+	mbe.Gender, mbe.GenderID = ParseGender(res.Gender.Value), res.Gender.ID
+
+	return nil
+}
+
+// MarshalXML is the counterpart of UnmarshalXML and encodes the MBID of the
+// gender element. Empty artists, alias and tag lists are omitted.
+func (mbe Artist) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+
+	if isZero(mbe) {
+		return nil
+	}
+
+	type artist Artist
+	res := struct {
+		*artist
+		Gender  *idValue         `xml:"gender"`
+		Aliases *xmlList[*Alias] `xml:"alias-list"`
+		Tags    *xmlList[Tag]    `xml:"tag-list"`
+	}{
+		artist:  (*artist)(&mbe),
+		Gender:  newIDValue(string(mbe.Gender), mbe.GenderID),
+		Aliases: newXMLList("alias", mbe.Aliases),
+		Tags:    newXMLList("tag", mbe.Tags),
 	}
-	res.Ptr = mbe
-	return &res
+
+	return e.EncodeElement(res, start)
+}
+
+func (mbe *Artist) lookupResult() interface{} {
+	return &lookupDoc{mbe}
 }
 
 func (mbe *Artist) apiEndpoint() string {
@@ -97,19 +143,14 @@ func (c *WS2Client) LookupArtist(id MBID, inc ...string) (*Artist, error) {
 // http://musicbrainz.org/doc/Development/XML_Web_Service/Version_2/Search#Artist
 func (c *WS2Client) SearchArtist(searchTerm string, limit, offset int) (*ArtistSearchResponse, error) {
 
-	result := artistListResult{}
-	err := c.searchRequest("/artist", &result, searchTerm, limit, offset)
-
-	rsp := ArtistSearchResponse{}
-	rsp.WS2ListResponse = result.ArtistList.WS2ListResponse
-	rsp.Scores = make(ScoreMap)
+	result, err := Search[*Artist](context.Background(), c, searchTerm, limit, offset)
 
-	for i, v := range result.ArtistList.Artists {
-		rsp.Artists = append(rsp.Artists, v.Artist)
-		rsp.Scores[rsp.Artists[i]] = v.Score
-	}
-
-	return &rsp, err
+	return &ArtistSearchResponse{
+		WS2ListResponse: result.WS2ListResponse,
+		Artists:         Entities(result.Results),
+		Scores:          scoreMap(result.Results),
+		Results:         result.Results,
+	}, err
 }
 
 // ArtistSearchResponse is the response type returned by the SearchArtist method.
@@ -117,25 +158,10 @@ type ArtistSearchResponse struct {
 	WS2ListResponse
 	Artists []*Artist
 	Scores  ScoreMap
+	Results []Scored[*Artist]
 }
 
 // ResultsWithScore returns a slice of Artists with a min score.
 func (r *ArtistSearchResponse) ResultsWithScore(score int) []*Artist {
-	var res []*Artist
-	for _, v := range r.Artists {
-		if r.Scores[v] >= score {
-			res = append(res, v)
-		}
-	}
-	return res
-}
-
-type artistListResult struct {
-	ArtistList struct {
-		WS2ListResponse
-		Artists []struct {
-			*Artist
-			Score int `xml:"http://musicbrainz.org/ns/ext#-2.0 score,attr"`
-		} `xml:"artist"`
-	} `xml:"artist-list"`
+	return Entities(FilterByScore(r.Results, score))
 }
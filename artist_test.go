@@ -101,6 +101,7 @@ func TestSearchArtist(t *testing.T) {
 	want.Scores = ScoreMap{
 		returned.Artists[0]: 100,
 	}
+	want.Results = []Scored[*Artist]{{Entity: returned.Artists[0], Score: 100}}
 
 	if !reflect.DeepEqual(*returned, want) {
 		t.Error(requestDiff(&want, returned))
@@ -183,7 +184,21 @@ func TestLookupArtist(t *testing.T) {
 							Accuracy: Day,
 						},
 						CountryCode: "US",
-						Barcode:     "724383988327",
+						ReleaseEvents: []*ReleaseEvent{
+							{
+								Date: BrainzTime{
+									Time:     time.Date(1995, 1, 24, 0, 0, 0, 0, time.UTC),
+									Accuracy: Day,
+								},
+								Area: Area{
+									ID:            "489ce91b-6658-3307-9877-795b68554c98",
+									Name:          "United States",
+									SortName:      "United States",
+									ISO31661Codes: []string{"US"},
+								},
+							},
+						},
+						Barcode: "724383988327",
 					},
 				},
 			},
@@ -0,0 +1,253 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SetCredentials sets the MusicBrainz username and password which are used
+// for requests that require authentication e.g. submissions. WS2 uses HTTP
+// digest authentication, so the password is never sent in plain text.
+func (c *WS2Client) SetCredentials(username, password string) {
+	c.username = username
+	c.password = password
+}
+
+// authRequest performs an authenticated request against the given endpoint.
+// If payload is not nil it is encoded as XML request body. The response is
+// decoded into data unless data is nil.
+func (c *WS2Client) authRequest(method string, data, payload interface{}, params url.Values, endpoint string) error {
+	return c.authRequestContext(context.Background(), method, data, payload, params, endpoint)
+}
+
+// authRequestContext is like authRequest but the request is canceled with ctx.
+func (c *WS2Client) authRequestContext(ctx context.Context, method string, data, payload interface{}, params url.Values, endpoint string) error {
+
+	if c.username == "" {
+		return errors.New("authentication required, no credentials set.")
+	}
+
+	var body []byte
+	if payload != nil {
+		var buf bytes.Buffer
+		buf.WriteString(xml.Header)
+		if err := xml.NewEncoder(&buf).Encode(payload); err != nil {
+			return err
+		}
+		body = buf.Bytes()
+	}
+
+	return c.request(ctx, method, data, body, params, endpoint, true)
+}
+
+// sendAuth performs a request like send and answers a digest challenge with
+// the credentials of c. It returns the response and the number of retries and
+// failovers.
+func (c *WS2Client) sendAuth(ctx context.Context, method string, params url.Values, endpoint string, body []byte) (*http.Response, int, error) {
+
+	resp, m, retries, err := c.send(ctx, method, params, endpoint, body)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, retries, err
+	}
+
+	challenge, err := parseDigestChallenge(resp.Header.Get("WWW-Authenticate"))
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, retries, err
+	}
+
+	// answer the challenge on the mirror which sent it
+	req, err := c.newRequest(ctx, m.base, method, params, endpoint, body)
+	if err != nil {
+		return nil, retries, err
+	}
+	auth, err := challenge.authorization(c.username, c.password, method, req.URL.RequestURI())
+	if err != nil {
+		return nil, retries, err
+	}
+	req.Header.Set("Authorization", auth)
+
+	resp, err = m.do(req)
+	return resp, retries, err
+}
+
+// digestChallenge holds the parameters of a WWW-Authenticate digest challenge
+// as described in RFC 2617.
+type digestChallenge struct {
+	realm     string
+	nonce     string
+	opaque    string
+	algorithm string
+	qop       []string
+}
+
+func parseDigestChallenge(header string) (*digestChallenge, error) {
+
+	if !strings.HasPrefix(strings.ToLower(header), "digest ") {
+		return nil, fmt.Errorf("unsupported authentication challenge %q", header)
+	}
+
+	ch := &digestChallenge{}
+
+	for key, value := range parseAuthParams(header[len("digest "):]) {
+		switch key {
+		case "realm":
+			ch.realm = value
+		case "nonce":
+			ch.nonce = value
+		case "opaque":
+			ch.opaque = value
+		case "algorithm":
+			ch.algorithm = value
+		case "qop":
+			for _, qop := range strings.Split(value, ",") {
+				ch.qop = append(ch.qop, strings.TrimSpace(qop))
+			}
+		}
+	}
+
+	if ch.nonce == "" {
+		return nil, errors.New("digest challenge without nonce.")
+	}
+	return ch, nil
+}
+
+// parseAuthParams splits a comma separated list of key=value pairs where
+// values may be quoted strings containing commas.
+func parseAuthParams(s string) map[string]string {
+
+	params := make(map[string]string)
+
+	for {
+		s = strings.TrimLeft(s, " \t,")
+		if s == "" {
+			return params
+		}
+
+		eq := strings.IndexByte(s, '=')
+		if eq < 0 {
+			return params
+		}
+		key := strings.ToLower(strings.TrimSpace(s[:eq]))
+		s = strings.TrimLeft(s[eq+1:], " \t")
+
+		var value string
+		if strings.HasPrefix(s, `"`) {
+			var b strings.Builder
+			i := 1
+			for ; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' && i+1 < len(s) {
+					i++
+				}
+				b.WriteByte(s[i])
+			}
+			value = b.String()
+			if i < len(s) {
+				i++ // closing quote
+			}
+			s = s[i:]
+		} else {
+			end := strings.IndexByte(s, ',')
+			if end < 0 {
+				end = len(s)
+			}
+			value = strings.TrimSpace(s[:end])
+			s = s[end:]
+		}
+		params[key] = value
+	}
+}
+
+// authorization returns the Authorization header value answering the
+// challenge for the given request.
+func (ch *digestChallenge) authorization(username, password, method, uri string) (string, error) {
+
+	var algorithm string
+	switch strings.ToUpper(ch.algorithm) {
+	case "", "MD5":
+		algorithm = "MD5"
+	case "MD5-SESS":
+		algorithm = "MD5-sess"
+	default:
+		return "", fmt.Errorf("unsupported digest algorithm %q", ch.algorithm)
+	}
+
+	cnonceBytes := make([]byte, 8)
+	if _, err := rand.Read(cnonceBytes); err != nil {
+		return "", err
+	}
+	cnonce := hex.EncodeToString(cnonceBytes)
+	nc := "00000001"
+
+	ha1 := md5Hex(username + ":" + ch.realm + ":" + password)
+	if algorithm == "MD5-sess" {
+		ha1 = md5Hex(ha1 + ":" + ch.nonce + ":" + cnonce)
+	}
+	ha2 := md5Hex(method + ":" + uri)
+
+	var qop string
+	for _, q := range ch.qop {
+		if q == "auth" {
+			qop = q
+		}
+	}
+
+	var response string
+	if qop != "" {
+		response = md5Hex(ha1 + ":" + ch.nonce + ":" + nc + ":" + cnonce + ":" + qop + ":" + ha2)
+	} else {
+		response = md5Hex(ha1 + ":" + ch.nonce + ":" + ha2)
+	}
+
+	auth := fmt.Sprintf(`Digest username="%s", realm="%s", nonce="%s", uri="%s", response="%s", algorithm=%s`,
+		username, ch.realm, ch.nonce, uri, response, algorithm)
+	if qop != "" {
+		auth += fmt.Sprintf(`, qop=%s, nc=%s, cnonce="%s"`, qop, nc, cnonce)
+	}
+	if ch.opaque != "" {
+		auth += fmt.Sprintf(`, opaque="%s"`, ch.opaque)
+	}
+
+	return auth, nil
+}
+
+func md5Hex(s string) string {
+	sum := md5.Sum([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const (
+	testUsername = "gopher"
+	testPassword = "secret"
+	testRealm    = "musicbrainz.org"
+	testNonce    = "dcd98b7102dd2f0e8b11d0f600bfb0c093"
+	testOpaque   = "5ccc069c403ebaf9f0171e9517f40e41"
+)
+
+// serveDigestAuth registers handler for endpoint behind a HTTP digest
+// authentication stand-in that accepts testUsername and testPassword.
+func serveDigestAuth(endpoint string, t *testing.T, handler http.HandlerFunc) {
+
+	mux.HandleFunc(endpoint, func(w http.ResponseWriter, r *http.Request) {
+		t.Log(r.Method, "request was:", r.URL.String())
+
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "Digest ") {
+			w.Header().Set("WWW-Authenticate",
+				`Digest realm="`+testRealm+`", nonce="`+testNonce+`", qop="auth", algorithm=MD5, opaque="`+testOpaque+`"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		p := parseAuthParams(auth[len("Digest "):])
+
+		ha1 := md5Hex(testUsername + ":" + testRealm + ":" + testPassword)
+		ha2 := md5Hex(r.Method + ":" + r.URL.RequestURI())
+		want := md5Hex(ha1 + ":" + testNonce + ":" + p["nc"] + ":" + p["cnonce"] + ":" + p["qop"] + ":" + ha2)
+
+		if p["username"] != testUsername || p["uri"] != r.URL.RequestURI() ||
+			p["opaque"] != testOpaque || p["response"] != want {
+			t.Errorf("invalid digest authorization %q", auth)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		handler(w, r)
+	})
+}
+
+func TestParseDigestChallenge(t *testing.T) {
+
+	ch, err := parseDigestChallenge(
+		`Digest realm="musicbrainz.org", qop="auth,auth-int", nonce="abc", opaque="x\"y"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ch.realm != "musicbrainz.org" || ch.nonce != "abc" || ch.opaque != `x"y` {
+		t.Errorf("unexpected challenge %+v", ch)
+	}
+	if len(ch.qop) != 2 || ch.qop[0] != "auth" || ch.qop[1] != "auth-int" {
+		t.Errorf("unexpected qop %v", ch.qop)
+	}
+
+	if _, err := parseDigestChallenge(`Basic realm="musicbrainz.org"`); err == nil {
+		t.Error("expected error for basic challenge")
+	}
+}
+
+func TestAuthRequestWithoutCredentials(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+
+	if err := client.SubmitRatings(); err == nil {
+		t.Error("expected error for missing credentials")
+	}
+}
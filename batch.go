@@ -0,0 +1,162 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"context"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// DefaultBatchWorkers is the number of concurrent lookups of BatchLookup if
+// BatchOptions.Workers is not set.
+const DefaultBatchWorkers = 4
+
+// BatchItem is a single lookup of a batch. Entity must be a pointer to an
+// entity with its ID set, e.g. &Artist{ID: id}, which is filled by the
+// lookup.
+type BatchItem struct {
+	Entity MBLookupEntity
+	Inc    []string
+}
+
+// BatchOptions configures BatchLookup.
+type BatchOptions struct {
+	// Workers is the maximum number of concurrent lookups. The rate limit of
+	// the WS2Client applies to all of them, see SetRateLimit.
+	Workers int
+
+	// Ordered makes BatchLookup return results in the order of the items.
+	// Otherwise results are returned as soon as they complete.
+	Ordered bool
+}
+
+// BatchResult is the result of the BatchItem at position Index. Entity is the
+// entity of the item.
+type BatchResult struct {
+	Index  int
+	Entity MBLookupEntity
+	Err    error
+}
+
+// batchJob is a lookup shared by all items with identical requests.
+type batchJob struct {
+	item    BatchItem
+	indices []int
+	err     error
+}
+
+// BatchLookup looks up the entities of items concurrently and streams one
+// BatchResult per item through the returned channel, which is closed when all
+// items are done. The channel must be drained. Identical lookups (same entity
+// type, MBID and includes) are performed once and copied into the entities of
+// all those items. If ctx is canceled, outstanding lookups fail with the
+// context's error.
+func (c *WS2Client) BatchLookup(ctx context.Context, items []BatchItem, opts BatchOptions) <-chan BatchResult {
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = DefaultBatchWorkers
+	}
+
+	var jobs []*batchJob
+	byKey := make(map[string]*batchJob)
+	for i, item := range items {
+		key := batchKey(item)
+		if j, ok := byKey[key]; ok {
+			j.indices = append(j.indices, i)
+			continue
+		}
+		j := &batchJob{item: item, indices: []int{i}}
+		byKey[key] = j
+		jobs = append(jobs, j)
+	}
+
+	pending := make(chan *batchJob)
+	done := make(chan *batchJob)
+	out := make(chan BatchResult)
+
+	go func() {
+		defer close(pending)
+		for _, j := range jobs {
+			pending <- j
+		}
+	}()
+
+	if workers > len(jobs) {
+		workers = len(jobs)
+	}
+	for w := 0; w < workers; w++ {
+		go func() {
+			for j := range pending {
+				j.err = c.lookup(ctx, j.item.Entity, j.item.Entity.Id(), j.item.Inc)
+				done <- j
+			}
+		}()
+	}
+
+	go func() {
+		defer close(out)
+
+		results := make([]*BatchResult, len(items))
+		next := 0
+
+		for range jobs {
+			j := <-done
+			for _, i := range j.indices {
+				if i != j.indices[0] {
+					copyEntity(items[i].Entity, j.item.Entity)
+				}
+				r := &BatchResult{Index: i, Entity: items[i].Entity, Err: j.err}
+				if !opts.Ordered {
+					out <- *r
+				}
+				results[i] = r
+			}
+			for opts.Ordered && next < len(results) && results[next] != nil {
+				out <- *results[next]
+				next++
+			}
+		}
+	}()
+
+	return out
+}
+
+// batchKey identifies identical lookups.
+func batchKey(item BatchItem) string {
+	inc := append([]string(nil), item.Inc...)
+	sort.Strings(inc)
+	return path.Join(item.Entity.apiEndpoint(), string(item.Entity.Id())) +
+		"?" + strings.Join(inc, "+")
+}
+
+// copyEntity copies the entity src points to into dst.
+func copyEntity(dst, src MBLookupEntity) {
+	reflect.ValueOf(dst).Elem().Set(reflect.ValueOf(src).Elem())
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+func TestBatchLookup(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+
+	var hits int32
+	serve := func(endpoint, testfile string) {
+		mux.HandleFunc(endpoint, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&hits, 1)
+			http.ServeFile(w, r, path.Join("./testdata", testfile))
+		})
+	}
+	serve("/artist/10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8", "LookupArtist.xml")
+	serve("/release-group/1dc4c347-a1db-32aa-b14f-bc9cc507b843", "LookupReleaseGroup.xml")
+	mux.HandleFunc("/label/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+	})
+
+	wantArtist, err := client.LookupArtist("10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantGroup, err := client.LookupReleaseGroup("1dc4c347-a1db-32aa-b14f-bc9cc507b843")
+	if err != nil {
+		t.Fatal(err)
+	}
+	atomic.StoreInt32(&hits, 0)
+
+	items := []BatchItem{
+		{Entity: &Artist{ID: "10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8"}},
+		{Entity: &ReleaseGroup{ID: "1dc4c347-a1db-32aa-b14f-bc9cc507b843"}},
+		{Entity: &Label{ID: "46f0f4cd-8aab-4b33-b698-f459faf64190"}},
+		{Entity: &Artist{ID: "10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8"}},
+	}
+
+	var results []BatchResult
+	for r := range client.BatchLookup(context.Background(), items, BatchOptions{Workers: 2, Ordered: true}) {
+		results = append(results, r)
+	}
+
+	if len(results) != len(items) {
+		t.Fatalf("got %d results, want %d", len(results), len(items))
+	}
+	for i, r := range results {
+		if r.Index != i {
+			t.Errorf("result %d has index %d", i, r.Index)
+		}
+		if r.Entity != items[i].Entity {
+			t.Errorf("result %d does not hold the entity of its item", i)
+		}
+	}
+
+	for _, i := range []int{0, 3} {
+		if results[i].Err != nil {
+			t.Errorf("result %d: %v", i, results[i].Err)
+		}
+		if !reflect.DeepEqual(results[i].Entity, wantArtist) {
+			t.Error(requestDiff(wantArtist, results[i].Entity))
+		}
+	}
+	if !reflect.DeepEqual(results[1].Entity, wantGroup) {
+		t.Error(requestDiff(wantGroup, results[1].Entity))
+	}
+
+	var ws2Err *WS2Error
+	if !errors.As(results[2].Err, &ws2Err) || ws2Err.StatusCode != http.StatusNotFound {
+		t.Errorf("got error %v, want WS2Error with status 404", results[2].Err)
+	}
+
+	if n := atomic.LoadInt32(&hits); n != 2 {
+		t.Errorf("server got %d requests, want 2", n)
+	}
+}
+
+func TestBatchLookupCanceled(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+	serveTestFile("/artist/10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8", "LookupArtist.xml", t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	items := []BatchItem{
+		{Entity: &Artist{ID: "10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8"}},
+		{Entity: &Artist{ID: "10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8"}, Inc: []string{"aliases"}},
+	}
+
+	n := 0
+	for r := range client.BatchLookup(ctx, items, BatchOptions{}) {
+		n++
+		if !errors.Is(r.Err, context.Canceled) {
+			t.Errorf("result %d: got error %v, want context.Canceled", r.Index, r.Err)
+		}
+	}
+	if n != len(items) {
+		t.Errorf("got %d results, want %d", n, len(items))
+	}
+}
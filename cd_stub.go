@@ -25,18 +25,24 @@
 
 package gomusicbrainz
 
+import "context"
+
 // CDStub represents an anonymously submitted track list.
 type CDStub struct {
 	ID        string `xml:"id,attr"` // seems not to be a valid MBID (UUID)
-	Title     string `xml:"title"`
-	Artist    string `xml:"artist"`
-	Barcode   string `xml:"barcode"`
-	Comment   string `xml:"comment"`
+	Title     string `xml:"title,omitempty"`
+	Artist    string `xml:"artist,omitempty"`
+	Barcode   string `xml:"barcode,omitempty"`
+	Comment   string `xml:"comment,omitempty"`
 	TrackList struct {
 		Count int `xml:"count,attr"`
 	} `xml:"track-list"`
 }
 
+func (mbe *CDStub) apiEndpoint() string {
+	return "/cdstub"
+}
+
 // SearchCDStub queries MusicBrainz´ Search Server for CDStubs.
 //
 // Possible search fields to provide in searchTerm are:
@@ -53,19 +59,14 @@ type CDStub struct {
 // https://musicbrainz.org/doc/Development/XML_Web_Service/Version_2/Search#CDStubs
 func (c *WS2Client) SearchCDStub(searchTerm string, limit, offset int) (*CDStubSearchResponse, error) {
 
-	result := cdStubListResult{}
-	err := c.searchRequest("/cdstub", &result, searchTerm, limit, offset)
-
-	rsp := CDStubSearchResponse{}
-	rsp.WS2ListResponse = result.CDStubList.WS2ListResponse
-	rsp.Scores = make(ScoreMap)
+	result, err := Search[*CDStub](context.Background(), c, searchTerm, limit, offset)
 
-	for i, v := range result.CDStubList.CDStubs {
-		rsp.CDStubs = append(rsp.CDStubs, v.CDStub)
-		rsp.Scores[rsp.CDStubs[i]] = v.Score
-	}
-
-	return &rsp, err
+	return &CDStubSearchResponse{
+		WS2ListResponse: result.WS2ListResponse,
+		CDStubs:         Entities(result.Results),
+		Scores:          scoreMap(result.Results),
+		Results:         result.Results,
+	}, err
 }
 
 // CDStubSearchResponse is the response type returned by the SearchCDStub method.
@@ -73,25 +74,10 @@ type CDStubSearchResponse struct {
 	WS2ListResponse
 	CDStubs []*CDStub
 	Scores  ScoreMap
+	Results []Scored[*CDStub]
 }
 
 // ResultsWithScore returns a slice of CDStubs with a min score.
 func (r *CDStubSearchResponse) ResultsWithScore(score int) []*CDStub {
-	var res []*CDStub
-	for _, v := range r.CDStubs {
-		if r.Scores[v] >= score {
-			res = append(res, v)
-		}
-	}
-	return res
-}
-
-type cdStubListResult struct {
-	CDStubList struct {
-		WS2ListResponse
-		CDStubs []struct {
-			*CDStub
-			Score int `xml:"http://musicbrainz.org/ns/ext#-2.0 score,attr"`
-		} `xml:"cdstub"`
-	} `xml:"cdstub-list"`
+	return Entities(FilterByScore(r.Results, score))
 }
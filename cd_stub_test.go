@@ -65,6 +65,7 @@ func TestSearchCDStub(t *testing.T) {
 	want.Scores = ScoreMap{
 		returned.CDStubs[0]: 100,
 	}
+	want.Results = []Scored[*CDStub]{{Entity: returned.CDStubs[0], Score: 100}}
 
 	if !reflect.DeepEqual(*returned, want) {
 		t.Error(requestDiff(&want, returned))
@@ -0,0 +1,135 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// flightGroup coalesces concurrent identical requests. Callers of do with the
+// same key share the response of a single request.
+type flightGroup struct {
+	mu sync.Mutex
+	m  map[string]*flight
+}
+
+// flight is a request in progress.
+type flight struct {
+	done    chan struct{}
+	res     *fetched
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// fetched is the outcome of a request.
+type fetched struct {
+	body    []byte
+	status  int // 0 without response
+	retries int // throttling retries and failovers, reported for the leader only
+}
+
+// do returns the outcome of fetch for key. If a request for key is already in
+// flight, its outcome is awaited instead of calling fetch again and shared is
+// true. The request is independent of the contexts of single callers and only
+// canceled once all of them gave up.
+func (g *flightGroup) do(ctx context.Context, key string, fetch func(context.Context) (*fetched, error)) (res *fetched, shared bool, err error) {
+
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
+	}
+
+	g.mu.Lock()
+	if g.m == nil {
+		g.m = make(map[string]*flight)
+	}
+	f, shared := g.m[key]
+	if !shared {
+		fctx, cancel := context.WithCancel(context.Background())
+		f = &flight{done: make(chan struct{}), cancel: cancel}
+		g.m[key] = f
+
+		go func() {
+			f.res, f.err = fetch(fctx)
+
+			g.mu.Lock()
+			if g.m[key] == f {
+				delete(g.m, key)
+			}
+			g.mu.Unlock()
+
+			cancel()
+			close(f.done)
+		}()
+	}
+	f.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.res, shared, f.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		f.waiters--
+		if f.waiters == 0 {
+			f.cancel()
+			if g.m[key] == f {
+				delete(g.m, key)
+			}
+		}
+		g.mu.Unlock()
+		return nil, shared, ctx.Err()
+	}
+}
+
+// requestKey identifies a GET request by endpoint and normalized params.
+// Empty params are dropped and the order of inc arguments is ignored.
+func requestKey(endpoint string, params url.Values) string {
+
+	norm := url.Values{}
+	for k, vals := range params {
+		for _, v := range vals {
+			if v == "" {
+				continue
+			}
+			if k == "inc" {
+				incs := strings.Split(v, "+")
+				sort.Strings(incs)
+				v = strings.Join(incs, "+")
+			}
+			norm.Add(k, v)
+		}
+	}
+	for _, vals := range norm {
+		sort.Strings(vals)
+	}
+
+	return endpoint + "?" + norm.Encode()
+}
@@ -0,0 +1,214 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"path"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// waitForWaiters blocks until n callers wait for the request with key.
+func waitForWaiters(t *testing.T, key string, n int) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		client.flights.mu.Lock()
+		f := client.flights.m[key]
+		waiting := f != nil && f.waiters == n
+		client.flights.mu.Unlock()
+		if waiting {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timeout waiting for %d callers of %s", n, key)
+}
+
+func TestCoalescedLookup(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+
+	var hits int32
+	release := make(chan struct{})
+	mux.HandleFunc("/artist/10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		<-release
+		http.ServeFile(w, r, path.Join("./testdata", "LookupArtist.xml"))
+	})
+
+	const callers = 5
+	artists := make([]*Artist, callers)
+	errs := make([]error, callers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				artists[i], errs[i] = client.LookupArtist("10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8")
+			} else {
+				artists[i], errs[i] = Lookup[*Artist](context.Background(), client, "10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8")
+			}
+		}(i)
+	}
+
+	waitForWaiters(t, "/artist/10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8?", callers)
+	close(release)
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&hits); n != 1 {
+		t.Errorf("server got %d requests, want 1", n)
+	}
+	for i := range artists {
+		if errs[i] != nil {
+			t.Fatalf("caller %d: %v", i, errs[i])
+		}
+		if i > 0 && artists[i] == artists[0] {
+			t.Errorf("caller %d shares the result of caller 0", i)
+		}
+		if !reflect.DeepEqual(artists[i], artists[0]) {
+			t.Error(requestDiff(artists[0], artists[i]))
+		}
+	}
+}
+
+func TestCoalescedLookupRetries(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+
+	var hits int32
+	release := make(chan struct{})
+	mux.HandleFunc("/artist/10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		<-release
+		http.ServeFile(w, r, path.Join("./testdata", "LookupArtist.xml"))
+	})
+
+	counters := NewExpvarHook("")
+	client.Hooks = []RequestHook{counters}
+
+	const callers = 3
+	errs := make([]error, callers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = client.LookupArtist("10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8")
+		}(i)
+	}
+
+	waitForWaiters(t, "/artist/10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8?", callers)
+	close(release)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("caller %d: %v", i, err)
+		}
+	}
+
+	// only the caller which sent the request reports its retry
+	vars := counters.Vars()
+	for key, want := range map[string]string{"requests": "3", "retries": "1", "cache_hits": "2"} {
+		if got := vars.Get(key).String(); got != want {
+			t.Errorf("counter %s is %s, want %s", key, got, want)
+		}
+	}
+}
+
+func TestCoalescedLookupCanceled(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+
+	release := make(chan struct{})
+	mux.HandleFunc("/artist/10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8", func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		http.ServeFile(w, r, path.Join("./testdata", "LookupArtist.xml"))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := make(chan error)
+	go func() {
+		_, err := Lookup[*Artist](ctx, client, "10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8")
+		canceled <- err
+	}()
+	waitForWaiters(t, "/artist/10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8?", 1)
+
+	done := make(chan error)
+	go func() {
+		_, err := client.LookupArtist("10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8")
+		done <- err
+	}()
+	waitForWaiters(t, "/artist/10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8?", 2)
+
+	// the first caller giving up must not cancel the shared request
+	cancel()
+	if err := <-canceled; err != context.Canceled {
+		t.Errorf("got %v, want context.Canceled", err)
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRequestKey(t *testing.T) {
+
+	a := requestKey("/artist/10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8", url.Values{
+		"inc":    {"aliases+tags"},
+		"limit":  {""},
+		"offset": {""},
+	})
+	b := requestKey("/artist/10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8", url.Values{
+		"inc": {"tags+aliases"},
+	})
+	if a != b {
+		t.Errorf("got different keys %q and %q", a, b)
+	}
+
+	c := requestKey("/artist/10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8", url.Values{
+		"inc": {"tags"},
+	})
+	if a == c {
+		t.Errorf("got same key %q for different params", a)
+	}
+}
@@ -0,0 +1,308 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"encoding/xml"
+	"errors"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// maxCollectionBatch is the maximum number of MBIDs WS2 accepts per
+// collection modification request.
+const maxCollectionBatch = 400
+
+// Collection represents a user defined list of entities e.g. the releases a
+// user owns. Collections can be public or private. See
+// https://musicbrainz.org/doc/Collections
+type Collection struct {
+	ID         MBID   `xml:"id,attr"`
+	Type       string `xml:"type,attr,omitempty"`
+	EntityType string `xml:"entity-type,attr,omitempty"`
+	Name       string `xml:"name,omitempty"`
+	Editor     string `xml:"editor,omitempty"`
+
+	// ItemCount is the number of entities in the collection.
+	ItemCount int `xml:"-"`
+}
+
+// UnmarshalXML is needed to implement XMLUnmarshaler for collections which
+// provide their item count in a <ENTITY>-list element.
+func (mbe *Collection) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+
+	type collection Collection
+	var res struct {
+		*collection
+		Lists []struct {
+			XMLName xml.Name
+			Count   int `xml:"count,attr"`
+		} `xml:",any"`
+	}
+	res.collection = (*collection)(mbe)
+
+	if err := d.DecodeElement(&res, &start); err != nil {
+		return err
+	}
+
+	for _, l := range res.Lists {
+		if strings.HasSuffix(l.XMLName.Local, "-list") {
+			mbe.ItemCount = l.Count
+		}
+	}
+	return nil
+}
+
+// MarshalXML is the counterpart of UnmarshalXML and encodes the item count in
+// a <ENTITY>-list element.
+func (mbe Collection) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+
+	type itemList struct {
+		XMLName xml.Name
+		Count   int `xml:"count,attr"`
+	}
+	type collection Collection
+	res := struct {
+		*collection
+		List *itemList
+	}{collection: (*collection)(&mbe)}
+
+	if mbe.EntityType != "" {
+		name := strings.Replace(mbe.EntityType, "_", "-", -1) + "-list"
+		res.List = &itemList{XMLName: xml.Name{Local: name}, Count: mbe.ItemCount}
+	}
+
+	return e.EncodeElement(res, start)
+}
+
+func (mbe *Collection) lookupResult() interface{} {
+	return &lookupDoc{mbe}
+}
+
+func (mbe *Collection) apiEndpoint() string {
+	return "/collection"
+}
+
+func (mbe *Collection) Id() MBID {
+	return mbe.ID
+}
+
+// LookupCollection performs a collection lookup request for the given MBID.
+// Private collections can only be looked up by their owner, see
+// SetCredentials.
+func (c *WS2Client) LookupCollection(id MBID, inc ...string) (*Collection, error) {
+	a := &Collection{ID: id}
+
+	var err error
+	if c.username != "" {
+		if id, err = ParseMBID(string(id)); err != nil {
+			return a, err
+		}
+		err = c.authRequest("GET", a.lookupResult(), nil, encodeInc(inc),
+			path.Join(a.apiEndpoint(), string(id)))
+	} else {
+		err = c.Lookup(a, inc...)
+	}
+
+	return a, err
+}
+
+// CollectionListResponse is the response type returned by the
+// UserCollections method.
+type CollectionListResponse struct {
+	WS2ListResponse
+	Collections []*Collection
+}
+
+// UserCollections returns the public and private collections of the
+// authenticated user. This requires authentication, see SetCredentials.
+func (c *WS2Client) UserCollections(limit, offset int) (*CollectionListResponse, error) {
+
+	var result struct {
+		CollectionList struct {
+			WS2ListResponse
+			Collections []*Collection `xml:"collection"`
+		} `xml:"collection-list"`
+	}
+
+	params := url.Values{
+		"editor": {c.username},
+		"limit":  {intParamToString(limit)},
+		"offset": {intParamToString(offset)},
+	}
+
+	err := c.authRequest("GET", &result, nil, params, "/collection")
+
+	return &CollectionListResponse{
+		WS2ListResponse: result.CollectionList.WS2ListResponse,
+		Collections:     result.CollectionList.Collections,
+	}, err
+}
+
+// ReleaseBrowseResponse is the response type returned by release browse
+// requests.
+type ReleaseBrowseResponse struct {
+	WS2ListResponse
+	Releases []*Release
+}
+
+// BrowseCollectionReleases returns the releases of a collection. The
+// collection's owner can browse private collections, see SetCredentials.
+func (c *WS2Client) BrowseCollectionReleases(collection MBID, limit, offset int, inc ...string) (*ReleaseBrowseResponse, error) {
+
+	var result SearchResponse[*Release]
+	err := c.browseRequest("/release", &result, "collection", collection, limit, offset, inc)
+
+	return &ReleaseBrowseResponse{
+		WS2ListResponse: result.WS2ListResponse,
+		Releases:        Entities(result.Results),
+	}, err
+}
+
+// ArtistBrowseResponse is the response type returned by artist browse
+// requests.
+type ArtistBrowseResponse struct {
+	WS2ListResponse
+	Artists []*Artist
+}
+
+// BrowseCollectionArtists returns the artists of a collection. The
+// collection's owner can browse private collections, see SetCredentials.
+func (c *WS2Client) BrowseCollectionArtists(collection MBID, limit, offset int, inc ...string) (*ArtistBrowseResponse, error) {
+
+	var result SearchResponse[*Artist]
+	err := c.browseRequest("/artist", &result, "collection", collection, limit, offset, inc)
+
+	return &ArtistBrowseResponse{
+		WS2ListResponse: result.WS2ListResponse,
+		Artists:         Entities(result.Results),
+	}, err
+}
+
+// EventBrowseResponse is the response type returned by event browse requests.
+type EventBrowseResponse struct {
+	WS2ListResponse
+	Events []*Event
+}
+
+// BrowseCollectionEvents returns the events of a collection. The collection's
+// owner can browse private collections, see SetCredentials.
+func (c *WS2Client) BrowseCollectionEvents(collection MBID, limit, offset int, inc ...string) (*EventBrowseResponse, error) {
+
+	var result SearchResponse[*Event]
+	err := c.browseRequest("/event", &result, "collection", collection, limit, offset, inc)
+
+	return &EventBrowseResponse{
+		WS2ListResponse: result.WS2ListResponse,
+		Events:          Entities(result.Results),
+	}, err
+}
+
+// WorkBrowseResponse is the response type returned by work browse requests.
+type WorkBrowseResponse struct {
+	WS2ListResponse
+	Works []*Work
+}
+
+// BrowseCollectionWorks returns the works of a collection. The collection's
+// owner can browse private collections, see SetCredentials.
+func (c *WS2Client) BrowseCollectionWorks(collection MBID, limit, offset int, inc ...string) (*WorkBrowseResponse, error) {
+
+	var result SearchResponse[*Work]
+	err := c.browseRequest("/work", &result, "collection", collection, limit, offset, inc)
+
+	return &WorkBrowseResponse{
+		WS2ListResponse: result.WS2ListResponse,
+		Works:           Entities(result.Results),
+	}, err
+}
+
+// AddToCollection adds the given entities to a collection. Entities must match
+// the collection's entity type. Requests are split into batches of 400 MBIDs.
+// This requires authentication, see SetCredentials.
+func (c *WS2Client) AddToCollection(collection MBID, entities ...MBEntity) error {
+	return c.modifyCollection("PUT", collection, entities)
+}
+
+// RemoveFromCollection removes the given entities from a collection. Requests
+// are split into batches of 400 MBIDs. This requires authentication, see
+// SetCredentials.
+func (c *WS2Client) RemoveFromCollection(collection MBID, entities ...MBEntity) error {
+	return c.modifyCollection("DELETE", collection, entities)
+}
+
+func (c *WS2Client) modifyCollection(method string, collection MBID, entities []MBEntity) error {
+
+	// MBIDs are joined into the path, so they must not contain separators.
+	collection, err := ParseMBID(string(collection))
+	if err != nil {
+		return err
+	}
+
+	// group MBIDs by the pluralized entity type e.g. "releases".
+	var types []string
+	ids := make(map[string][]string)
+
+	for _, e := range entities {
+		if e == nil || isZero(e) {
+			return errors.New("no entity to add or remove")
+		}
+		id, err := ParseMBID(string(e.Id()))
+		if err != nil {
+			return err
+		}
+
+		t := strings.TrimPrefix(e.apiEndpoint(), "/")
+		if !strings.HasSuffix(t, "s") {
+			t += "s"
+		}
+		if _, ok := ids[t]; !ok {
+			types = append(types, t)
+		}
+		ids[t] = append(ids[t], string(id))
+	}
+
+	params := url.Values{"client": {c.clientID}}
+
+	for _, t := range types {
+		for len(ids[t]) > 0 {
+			n := len(ids[t])
+			if n > maxCollectionBatch {
+				n = maxCollectionBatch
+			}
+			batch := ids[t][:n]
+			ids[t] = ids[t][n:]
+
+			endpoint := path.Join("/collection", string(collection), t, strings.Join(batch, ";"))
+			if err := c.authRequest(method, nil, nil, params, endpoint); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
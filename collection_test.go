@@ -0,0 +1,191 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUserCollections(t *testing.T) {
+
+	want := CollectionListResponse{
+		WS2ListResponse: WS2ListResponse{
+			Count:  2,
+			Offset: 0,
+		},
+		Collections: []*Collection{
+			{
+				ID:         "f4784850-3844-11e0-9e42-0800200c9a66",
+				Type:       "Release",
+				EntityType: "release",
+				Name:       "Shelf",
+				Editor:     "gopher",
+				ItemCount:  412,
+			},
+			{
+				ID:         "0b1d7f54-4f15-4a0e-9c3d-64b2c64c3e3b",
+				Type:       "Event",
+				EntityType: "event",
+				Name:       "Attended",
+				Editor:     "gopher",
+				ItemCount:  3,
+			},
+		},
+	}
+
+	setupHTTPTesting()
+	defer server.Close()
+	client.SetCredentials(testUsername, testPassword)
+
+	serveDigestAuth("/collection", t, func(w http.ResponseWriter, r *http.Request) {
+		if e := r.URL.Query().Get("editor"); e != testUsername {
+			t.Errorf("unexpected editor parameter %q", e)
+		}
+		http.ServeFile(w, r, path.Join("./testdata", "UserCollections.xml"))
+	})
+
+	returned, err := client.UserCollections(-1, -1)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(*returned, want) {
+		t.Error(requestDiff(&want, returned))
+	}
+}
+
+func TestBrowseCollectionReleases(t *testing.T) {
+
+	want := ReleaseBrowseResponse{
+		WS2ListResponse: WS2ListResponse{
+			Count:  412,
+			Offset: 25,
+		},
+		Releases: []*Release{
+			{
+				ID:     "07832b54-8266-47d5-bb0e-62c7f2cf5da5",
+				Title:  "Protection",
+				Status: "Official",
+				Date: BrainzTime{
+					Time:     time.Date(1995, 1, 24, 0, 0, 0, 0, time.UTC),
+					Accuracy: Day,
+				},
+				CountryCode: "US",
+				Barcode:     "724383988327",
+			},
+		},
+	}
+
+	setupHTTPTesting()
+	defer server.Close()
+
+	mux.HandleFunc("/release", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("collection") != "f4784850-3844-11e0-9e42-0800200c9a66" ||
+			q.Get("offset") != "25" {
+			t.Errorf("unexpected browse request %s", r.URL)
+		}
+		http.ServeFile(w, r, path.Join("./testdata", "BrowseCollectionReleases.xml"))
+	})
+
+	returned, err := client.BrowseCollectionReleases("f4784850-3844-11e0-9e42-0800200c9a66", 1, 25)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(*returned, want) {
+		t.Error(requestDiff(&want, returned))
+	}
+}
+
+func TestModifyCollection(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+	client.SetCredentials(testUsername, testPassword)
+
+	var releases []MBEntity
+	for i := 0; i < 401; i++ {
+		releases = append(releases, &Release{ID: MBID(fmt.Sprintf("00000000-0000-0000-0000-%012d", i))})
+	}
+
+	var batches []int
+	serveDigestAuth("/collection/f4784850-3844-11e0-9e42-0800200c9a66/releases/", t,
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != "PUT" {
+				t.Errorf("expected PUT request, got %s", r.Method)
+			}
+			batches = append(batches, len(strings.Split(path.Base(r.URL.Path), ";")))
+			w.Write([]byte(`<metadata><message><text>OK</text></message></metadata>`))
+		})
+
+	if err := client.AddToCollection("f4784850-3844-11e0-9e42-0800200c9a66", releases...); err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(batches, []int{400, 1}) {
+		t.Errorf("unexpected batch sizes %v", batches)
+	}
+}
+
+func TestModifyCollectionInvalidMBID(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+	client.SetCredentials(testUsername, testPassword)
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+	})
+
+	release := &Release{ID: "07832b54-8266-47d5-bb0e-62c7f2cf5da5"}
+
+	for _, id := range []MBID{"", "f4784850-3844-11e0-9e42-0800200c9a66/releases", "f4784850;x"} {
+		if err := client.AddToCollection(id, release); err == nil {
+			t.Errorf("AddToCollection(%q): expected error", id)
+		}
+	}
+
+	for _, id := range []MBID{"07832b54-8266-47d5-bb0e-62c7f2cf5da5;x", "../../artist", "07832b54?inc=x"} {
+		err := client.RemoveFromCollection("f4784850-3844-11e0-9e42-0800200c9a66", release, &Release{ID: id})
+		if err == nil {
+			t.Errorf("RemoveFromCollection with entity %q: expected error", id)
+		}
+	}
+
+	var none *Release
+	for _, e := range []MBEntity{nil, none} {
+		if err := client.AddToCollection("f4784850-3844-11e0-9e42-0800200c9a66", release, e); err == nil {
+			t.Errorf("AddToCollection with entity %#v: expected error", e)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// MBSearchEntity represents all entities which have a WS2 endpoint and can be
+// decoded from MMD documents, e.g. *Artist or *Annotation. It is used as
+// constraint for the generic Search and Lookup functions.
+type MBSearchEntity interface {
+	apiEndpoint() string
+}
+
+// elementName returns the name of the MMD element of an entity, which equals
+// its endpoint e.g. "release-group".
+func elementName(e MBSearchEntity) string {
+	return strings.TrimPrefix(e.apiEndpoint(), "/")
+}
+
+// newEntity returns a pointer to a new entity of type T, which must be a
+// pointer type like *Artist.
+func newEntity[T any]() T {
+	var zero T
+	return reflect.New(reflect.TypeOf(zero).Elem()).Interface().(T)
+}
+
+// metadataStart returns the start element of a MMD document.
+func metadataStart() xml.StartElement {
+	return xml.StartElement{
+		Name: xml.Name{Local: "metadata"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: mmdNamespace}},
+	}
+}
+
+// lookupDoc is the MMD document of a lookup response, i.e. the entity wrapped
+// in a metadata element. It is returned by the lookupResult methods of all
+// entities.
+type lookupDoc struct {
+	entity MBSearchEntity
+}
+
+func (l *lookupDoc) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+
+	if start.Name.Local != "metadata" {
+		return fmt.Errorf("unexpected element <%s>, want <metadata>", start.Name.Local)
+	}
+
+	name := elementName(l.entity)
+	found := false
+
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local == name && !found {
+				found = true
+				err = d.DecodeElement(l.entity, &t)
+			} else {
+				err = d.Skip()
+			}
+			if err != nil {
+				return err
+			}
+		case xml.EndElement:
+			if !found {
+				return fmt.Errorf("no %s element found", name)
+			}
+			return nil
+		}
+	}
+}
+
+func (l *lookupDoc) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+
+	start = metadataStart()
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	if err := e.EncodeElement(l.entity, xml.StartElement{Name: xml.Name{Local: elementName(l.entity)}}); err != nil {
+		return err
+	}
+	return e.EncodeToken(start.End())
+}
+
+// SearchResponse is the response type returned by Search. It is also used to
+// decode browse responses, whose results have a score of 0.
+type SearchResponse[T MBSearchEntity] struct {
+	WS2ListResponse
+	Results []Scored[T]
+}
+
+// UnmarshalXML decodes the <ENTITY>-list of a MMD document.
+func (r *SearchResponse[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+
+	name := elementName(newEntity[T]())
+
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local == name+"-list" {
+				err = r.decodeList(d, t, name)
+			} else {
+				err = d.Skip()
+			}
+			if err != nil {
+				return err
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+func (r *SearchResponse[T]) decodeList(d *xml.Decoder, start xml.StartElement, name string) error {
+
+	for _, a := range start.Attr {
+		switch a.Name.Local {
+		case "count":
+			r.Count, _ = strconv.Atoi(a.Value)
+		case "offset":
+			r.Offset, _ = strconv.Atoi(a.Value)
+		}
+	}
+
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Local != name {
+				if err := d.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			entity := newEntity[T]()
+			if err := d.DecodeElement(entity, &t); err != nil {
+				return err
+			}
+			r.Results = append(r.Results, Scored[T]{Entity: entity, Score: scoreAttr(t)})
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// MarshalXML encodes r as MMD document with scores as ext:score attributes.
+func (r SearchResponse[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+
+	name := elementName(newEntity[T]())
+
+	start = metadataStart()
+	list := xml.StartElement{
+		Name: xml.Name{Local: name + "-list"},
+		Attr: []xml.Attr{
+			{Name: xml.Name{Local: "count"}, Value: strconv.Itoa(r.Count)},
+			{Name: xml.Name{Local: "offset"}, Value: strconv.Itoa(r.Offset)},
+		},
+	}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	if err := e.EncodeToken(list); err != nil {
+		return err
+	}
+	for _, v := range r.Results {
+		item := xml.StartElement{
+			Name: xml.Name{Local: name},
+			Attr: []xml.Attr{newScoreAttr(v.Score)},
+		}
+		if err := e.EncodeElement(v.Entity, item); err != nil {
+			return err
+		}
+	}
+	if err := e.EncodeToken(list.End()); err != nil {
+		return err
+	}
+	return e.EncodeToken(start.End())
+}
+
+// scoreMap returns the ScoreMap of results.
+func scoreMap[T any](results []Scored[T]) ScoreMap {
+	scores := make(ScoreMap)
+	for _, r := range results {
+		scores[r.Entity] = r.Score
+	}
+	return scores
+}
+
+// lookup performs a lookup request for the entity with the given MBID. Invalid
+// MBIDs are rejected without a request, see ParseMBID. Lookups of merged
+// entities are reported to OnRedirect.
+func (c *WS2Client) lookup(ctx context.Context, entity MBLookupEntity, id MBID, inc []string) error {
+
+	if id == "" {
+		return errors.New("can't perform lookup without ID.")
+	}
+	id, err := ParseMBID(string(id))
+	if err != nil {
+		return err
+	}
+
+	err = c.getRequestContext(ctx, entity.lookupResult(), encodeInc(inc),
+		path.Join(entity.apiEndpoint(), string(id)))
+	if err != nil {
+		return err
+	}
+
+	c.reportRedirect(entity, id)
+	return nil
+}
+
+// Lookup performs a lookup request for an entity of type T with the given
+// MBID, e.g.
+//
+//	artist, err := gomusicbrainz.Lookup[*gomusicbrainz.Artist](ctx, client, id, "aliases")
+//
+// T must be a pointer to an entity type. The request is canceled if ctx is
+// done.
+func Lookup[T MBLookupEntity](ctx context.Context, c *WS2Client, id MBID, inc ...string) (T, error) {
+	entity := newEntity[T]()
+	err := c.lookup(ctx, entity, id, inc)
+	return entity, err
+}
+
+// Search queries MusicBrainz' search server for entities of type T, e.g.
+//
+//	rsp, err := gomusicbrainz.Search[*gomusicbrainz.Label](ctx, client, `label:"Atlantic"`, 10, -1)
+//
+// See the Search<ENTITY> methods for the supported fields of searchTerm and
+// the meaning of limit and offset. T must be a pointer to an entity type. The
+// request is canceled if ctx is done.
+func Search[T MBSearchEntity](ctx context.Context, c *WS2Client, searchTerm string, limit, offset int) (*SearchResponse[T], error) {
+	rsp := &SearchResponse[T]{}
+	err := c.searchRequest(ctx, newEntity[T]().apiEndpoint(), rsp, searchTerm, limit, offset)
+	return rsp, err
+}
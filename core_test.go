@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGenericLookup(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+	serveTestFile("/artist/10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8", "LookupArtist.xml", t)
+
+	want, err := client.LookupArtist("10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	returned, err := Lookup[*Artist](context.Background(), client, "10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(returned, want) {
+		t.Error(requestDiff(want, returned))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Lookup[*Artist](ctx, client, "10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8"); err == nil {
+		t.Error("expected error for canceled context")
+	}
+
+	if _, err := Lookup[*Artist](context.Background(), client, ""); err == nil {
+		t.Error("expected error for empty MBID")
+	}
+
+	// invalid MBIDs are rejected before a request is sent
+	mux.HandleFunc("/artist/10adbe5e", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request for invalid MBID")
+	})
+	if _, err := Lookup[*Artist](context.Background(), client, "10adbe5e"); err == nil {
+		t.Error("expected error for invalid MBID")
+	}
+
+	// MBIDs are normalized
+	upper, err := Lookup[*Artist](context.Background(), client, "10ADBE5E-A2C0-4BF3-8249-2B4CBF6E6CA8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(upper, want) {
+		t.Error(requestDiff(want, upper))
+	}
+}
+
+func TestGenericSearch(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+	serveTestFile("/label", "SearchLabel.xml", t)
+
+	want, err := client.SearchLabel("Atlantic", -1, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	returned, err := Search[*Label](context.Background(), client, "Atlantic", -1, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if returned.WS2ListResponse != want.WS2ListResponse ||
+		!reflect.DeepEqual(returned.Results, want.Results) {
+		t.Error(requestDiff(want.Results, returned.Results))
+	}
+}
@@ -0,0 +1,247 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// ErrNoCoverArt is returned by CAAClient if there is no cover art for the
+// requested release or release group.
+var ErrNoCoverArt = errors.New("no cover art available.")
+
+// CoverArtArchive contains the cover art information WS2 provides for a
+// release. It can be used to skip Cover Art Archive requests for releases
+// without artwork.
+type CoverArtArchive struct {
+	Artwork  bool `xml:"artwork"`
+	Count    int  `xml:"count"`
+	Front    bool `xml:"front"`
+	Back     bool `xml:"back"`
+	Darkened bool `xml:"darkened"`
+}
+
+// MarshalXML omits empty cover art information.
+func (c CoverArtArchive) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	type coverArtArchive CoverArtArchive
+	return encodeOptional(e, coverArtArchive(c), start)
+}
+
+// CoverArtSize selects the size of a cover art image to download.
+type CoverArtSize int
+
+const (
+	// SizeOriginal selects the image as uploaded.
+	SizeOriginal CoverArtSize = iota
+	Size250
+	Size500
+	Size1200
+)
+
+// CoverArtImage represents a single image of the Cover Art Archive.
+type CoverArtImage struct {
+	ID         json.Number       `json:"id"`
+	Types      []string          `json:"types"`
+	Front      bool              `json:"front"`
+	Back       bool              `json:"back"`
+	Comment    string            `json:"comment"`
+	Approved   bool              `json:"approved"`
+	Edit       int               `json:"edit"`
+	Image      string            `json:"image"`
+	Thumbnails map[string]string `json:"thumbnails"`
+}
+
+// URL returns the URL of the image in the given size. Thumbnails fall back on
+// the legacy "small" and "large" keys and on the original image if the
+// requested size is not available.
+func (img *CoverArtImage) URL(size CoverArtSize) string {
+
+	var keys []string
+
+	switch size {
+	case Size250:
+		keys = []string{"250", "small"}
+	case Size500:
+		keys = []string{"500", "large"}
+	case Size1200:
+		keys = []string{"1200"}
+	}
+
+	for _, k := range keys {
+		if u, ok := img.Thumbnails[k]; ok && u != "" {
+			return u
+		}
+	}
+	return img.Image
+}
+
+// HasType reports whether the image has the given type e.g. "Front", "Back",
+// "Booklet" or "Medium".
+func (img *CoverArtImage) HasType(t string) bool {
+	for _, v := range img.Types {
+		if strings.EqualFold(v, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// CoverArtListing is the list of images returned by the Cover Art Archive for
+// a release or release group. Release is the URL of the release the images
+// belong to.
+type CoverArtListing struct {
+	Images  []*CoverArtImage `json:"images"`
+	Release string           `json:"release"`
+}
+
+// FrontImage returns the image flagged as front cover or nil.
+func (l *CoverArtListing) FrontImage() *CoverArtImage {
+	for _, img := range l.Images {
+		if img.Front {
+			return img
+		}
+	}
+	return nil
+}
+
+// BackImage returns the image flagged as back cover or nil.
+func (l *CoverArtListing) BackImage() *CoverArtImage {
+	for _, img := range l.Images {
+		if img.Back {
+			return img
+		}
+	}
+	return nil
+}
+
+// NewCAAClient returns a new instance of CAAClient, a client for the Cover Art
+// Archive (e.g. https://coverartarchive.org). Please provide meaningful
+// information about your application like for NewWS2Client.
+func NewCAAClient(caaurl, appname, version, contact string) (*CAAClient, error) {
+	c := CAAClient{}
+	var err error
+
+	c.CAARootURL, err = url.Parse(caaurl)
+	if err != nil {
+		return nil, err
+	}
+	c.userAgentHeader = appname + "/" + version + " ( " + contact + " ) "
+
+	return &c, nil
+}
+
+// CAAClient defines a Go client for the Cover Art Archive API. See
+// https://musicbrainz.org/doc/Cover_Art_Archive/API
+type CAAClient struct {
+	CAARootURL      *url.URL // The API root URL
+	userAgentHeader string
+}
+
+// ReleaseImages returns the cover art images of the release with the given
+// MBID. ErrNoCoverArt is returned if the release has no cover art.
+func (c *CAAClient) ReleaseImages(id MBID) (*CoverArtListing, error) {
+	return c.listing("/release", id)
+}
+
+// ReleaseGroupImages returns the cover art images of the release selected as
+// cover for the release group with the given MBID.
+func (c *CAAClient) ReleaseGroupImages(id MBID) (*CoverArtListing, error) {
+	return c.listing("/release-group", id)
+}
+
+// listing returns the cover art listing of the entity with the given MBID.
+func (c *CAAClient) listing(endpoint string, id MBID) (*CoverArtListing, error) {
+
+	// the MBID is joined into the path, so it must not contain separators.
+	id, err := ParseMBID(string(id))
+	if err != nil {
+		return nil, err
+	}
+
+	reqUrl := *c.CAARootURL
+	reqUrl.Path = path.Join(reqUrl.Path, endpoint, string(id))
+
+	resp, err := c.get(reqUrl.String())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	listing := &CoverArtListing{}
+	if err = json.NewDecoder(resp.Body).Decode(listing); err != nil {
+		return nil, err
+	}
+	return listing, nil
+}
+
+// Download returns the image data of img in the given size. The caller must
+// close the returned ReadCloser.
+func (c *CAAClient) Download(img *CoverArtImage, size CoverArtSize) (io.ReadCloser, error) {
+
+	u := img.URL(size)
+	if u == "" {
+		return nil, errors.New("cover art image without URL.")
+	}
+
+	resp, err := c.get(u)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (c *CAAClient) get(u string) (*http.Response, error) {
+
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", c.userAgentHeader)
+
+	resp, err := newHTTPClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrNoCoverArt
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		resp.Body.Close()
+		return nil, fmt.Errorf("cover art archive: %s", resp.Status)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,185 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"io/ioutil"
+	"net/http"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func setupCAATesting(t *testing.T) *CAAClient {
+
+	caa, err := NewCAAClient(server.URL, "Application Name", "Version", "Contact")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return caa
+}
+
+// serveCoverArt serves a Cover Art Archive listing with image URLs pointing
+// to the test server.
+func serveCoverArt(endpoint string, testfile string, t *testing.T) {
+
+	mux.HandleFunc(endpoint, func(w http.ResponseWriter, r *http.Request) {
+		t.Log("GET request was:", r.URL.String())
+
+		data, err := ioutil.ReadFile(path.Join("./testdata", testfile))
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(strings.Replace(string(data), "{{SERVER}}", server.URL, -1)))
+	})
+}
+
+func TestReleaseImages(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+	serveCoverArt("/release/07832b54-8266-47d5-bb0e-62c7f2cf5da5", "CoverArtRelease.json", t)
+
+	want := CoverArtListing{
+		Images: []*CoverArtImage{
+			{
+				ID:       "829521842",
+				Types:    []string{"Front"},
+				Front:    true,
+				Approved: true,
+				Edit:     17462565,
+				Image:    server.URL + "/images/829521842.jpg",
+				Thumbnails: map[string]string{
+					"250":   server.URL + "/images/829521842-250.jpg",
+					"500":   server.URL + "/images/829521842-500.jpg",
+					"1200":  server.URL + "/images/829521842-1200.jpg",
+					"small": server.URL + "/images/829521842-250.jpg",
+					"large": server.URL + "/images/829521842-500.jpg",
+				},
+			},
+			{
+				ID:      "5769317885",
+				Types:   []string{"Back", "Spine"},
+				Back:    true,
+				Comment: "with spine",
+				Edit:    17462566,
+				Image:   server.URL + "/images/5769317885.jpg",
+				Thumbnails: map[string]string{
+					"small": server.URL + "/images/5769317885-250.jpg",
+					"large": server.URL + "/images/5769317885-500.jpg",
+				},
+			},
+		},
+		Release: "https://musicbrainz.org/release/07832b54-8266-47d5-bb0e-62c7f2cf5da5",
+	}
+
+	returned, err := setupCAATesting(t).ReleaseImages("07832b54-8266-47d5-bb0e-62c7f2cf5da5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(*returned, want) {
+		t.Error(requestDiff(&want, returned))
+	}
+
+	if returned.FrontImage() != returned.Images[0] || returned.BackImage() != returned.Images[1] {
+		t.Error("unexpected front or back image")
+	}
+	if !returned.Images[1].HasType("spine") {
+		t.Error("expected back image to have type Spine")
+	}
+	if u := returned.Images[1].URL(Size1200); u != returned.Images[1].Image {
+		t.Errorf("expected fallback on original image, got %s", u)
+	}
+}
+
+func TestReleaseGroupImagesNotFound(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+
+	_, err := setupCAATesting(t).ReleaseGroupImages("70664047-2545-4e46-b75f-4556f2a7b83e")
+	if err != ErrNoCoverArt {
+		t.Errorf("expected ErrNoCoverArt, got %v", err)
+	}
+}
+
+func TestReleaseImagesInvalidMBID(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+	})
+
+	caa := setupCAATesting(t)
+	for _, id := range []MBID{"", "../../release-group/70664047-2545-4e46-b75f-4556f2a7b83e", "76df3287-6cda-33eb-8e9a-044b5e15ffdd?x=1"} {
+		if _, err := caa.ReleaseImages(id); err == nil {
+			t.Errorf("ReleaseImages(%q): expected error", id)
+		}
+		if _, err := caa.ReleaseGroupImages(id); err == nil {
+			t.Errorf("ReleaseGroupImages(%q): expected error", id)
+		}
+	}
+
+	// MBIDs are normalized
+	serveCoverArt("/release/76df3287-6cda-33eb-8e9a-044b5e15ffdd", "CoverArtRelease.json", t)
+	if _, err := caa.ReleaseImages(" 76DF3287-6CDA-33EB-8E9A-044B5E15FFDD "); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDownloadCoverArt(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+
+	mux.HandleFunc("/images/829521842-500.jpg", func(w http.ResponseWriter, r *http.Request) {
+		if ua := r.Header.Get("User-Agent"); !strings.HasPrefix(ua, "Application Name/Version") {
+			t.Errorf("unexpected User-Agent %q", ua)
+		}
+		w.Write([]byte("JPEG"))
+	})
+
+	img := &CoverArtImage{
+		Image:      server.URL + "/images/829521842.jpg",
+		Thumbnails: map[string]string{"large": server.URL + "/images/829521842-500.jpg"},
+	}
+
+	rc, err := setupCAATesting(t).Download(img, Size500)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+
+	data, _ := ioutil.ReadAll(rc)
+	if string(data) != "JPEG" {
+		t.Errorf("unexpected image data %q", data)
+	}
+}
@@ -0,0 +1,424 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+/*
+Package cuesheet generates and parses CUE sheets of audio CD rips.
+
+FromRelease creates the CUE sheet of a medium of a MusicBrainz release, with
+INDEX positions taken from the TOC of its disc. Parse reads an existing CUE
+sheet, whose TOC can be used to look up the release of a rip:
+
+	sheet, err := cuesheet.Parse(f)
+	...
+	// frames is the length of the ripped audio file in CD frames, i.e.
+	// its number of samples / 588
+	toc, err := sheet.TOC(frames)
+	...
+	resp, err := client.LookupDiscID(toc.DiscID(), &toc, "recordings")
+
+Only sheets of disc images, i.e. a single audio file starting at the end of
+the two seconds pregap of the first track, are supported for TOC calculation.
+*/
+package cuesheet
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/michiwend/gomusicbrainz"
+)
+
+// FramesPerSecond is the number of frames (sectors) of a CD per second, the
+// unit of INDEX positions.
+const FramesPerSecond = 75
+
+// pregap is the number of frames before the first track of a CD.
+const pregap = 2 * FramesPerSecond
+
+// Sheet is a CUE sheet.
+type Sheet struct {
+	Comments  []string // REM lines without "REM "
+	Catalog   string   // 13 digit UPC/EAN
+	Performer string
+	Title     string
+	Tracks    []*Track
+}
+
+// Track is a TRACK of a CUE sheet and the FILE it is stored in.
+type Track struct {
+	File      string
+	FileType  string // e.g. "WAVE"
+	Number    int
+	Type      string // e.g. "AUDIO"
+	Title     string
+	Performer string
+	ISRC      string
+	Indexes   []Index
+}
+
+// Index is an INDEX of a track. Frames is its position in the file in CD
+// frames.
+type Index struct {
+	Number int
+	Frames int
+}
+
+// Start returns the position of INDEX 01 of t or -1 if there is none.
+func (t *Track) Start() int {
+	for _, i := range t.Indexes {
+		if i.Number == 1 {
+			return i.Frames
+		}
+	}
+	return -1
+}
+
+// FromRelease returns the CUE sheet of medium m of release r ripped to file
+// as WAVE. INDEX positions are taken from the first disc of m with one offset
+// per track, or calculated from the track lengths if there is none. Track
+// performers fall back to the artist of the recording and the release. Only
+// the first ISRC of each recording is used.
+func FromRelease(r *gomusicbrainz.Release, m *gomusicbrainz.Medium, file string) *Sheet {
+
+	s := &Sheet{
+		Performer: r.ArtistCredit.Name(),
+		Title:     r.Title,
+	}
+
+	if !r.Date.IsZero() {
+		s.Comments = append(s.Comments, "DATE "+strconv.Itoa(r.Date.Year()))
+	}
+	s.Comments = append(s.Comments, "MUSICBRAINZ_ALBUMID "+string(r.ID))
+
+	if n, err := gomusicbrainz.NormalizeBarcode(r.Barcode); err == nil {
+		switch len(n) {
+		case 12:
+			s.Catalog = "0" + n
+		case 13:
+			s.Catalog = n
+		}
+	}
+
+	var offsets []int
+	for _, d := range m.Discs {
+		if len(d.Offsets) == len(m.Tracks) {
+			offsets = d.Offsets
+			s.Comments = append(s.Comments, "MUSICBRAINZ_DISCID "+d.ID)
+			break
+		}
+	}
+
+	frames := 0
+	for i, t := range m.Tracks {
+
+		ct := &Track{
+			File:     file,
+			FileType: "WAVE",
+			Number:   t.Position,
+			Type:     "AUDIO",
+			Title:    t.Title,
+		}
+		if ct.Number == 0 {
+			ct.Number = i + 1
+		}
+		if ct.Title == "" {
+			ct.Title = t.Recording.Title
+		}
+
+		ac := t.ArtistCredit
+		if len(ac.NameCredits) == 0 {
+			ac = t.Recording.ArtistCredit
+		}
+		if len(ac.NameCredits) == 0 {
+			ac = r.ArtistCredit
+		}
+		ct.Performer = ac.Name()
+
+		if len(t.Recording.ISRCs) > 0 {
+			if isrc, err := gomusicbrainz.NormalizeISRC(t.Recording.ISRCs[0]); err == nil {
+				ct.ISRC = isrc
+			}
+		}
+
+		if offsets != nil {
+			frames = offsets[i] - pregap
+		}
+		ct.Indexes = []Index{{Number: 1, Frames: frames}}
+
+		length := t.Length
+		if length == 0 {
+			length = t.Recording.Length
+		}
+		frames += (length*FramesPerSecond + 500) / 1000
+
+		s.Tracks = append(s.Tracks, ct)
+	}
+
+	return s
+}
+
+// TOC returns the TOC of the audio tracks of s. fileFrames is the length of
+// the audio file in CD frames (its number of samples / 588), which determines
+// the lead-out. All tracks must be stored in the same file.
+func (s *Sheet) TOC(fileFrames int) (gomusicbrainz.TOC, error) {
+
+	var toc gomusicbrainz.TOC
+
+	file := ""
+	for _, t := range s.Tracks {
+		if t.Type != "AUDIO" {
+			continue
+		}
+		if toc.Offsets == nil {
+			file = t.File
+			toc.FirstTrack = t.Number
+		} else if t.File != file {
+			return toc, errors.New("tracks are stored in several files.")
+		}
+
+		start := t.Start()
+		if start < 0 {
+			return toc, fmt.Errorf("track %d has no INDEX 01", t.Number)
+		}
+		toc.LastTrack = t.Number
+		toc.Offsets = append(toc.Offsets, start+pregap)
+	}
+
+	if toc.Offsets == nil {
+		return toc, errors.New("no audio tracks.")
+	}
+	toc.LeadOut = fileFrames + pregap
+
+	return toc, toc.Validate()
+}
+
+// String returns s in CUE sheet syntax.
+func (s *Sheet) String() string {
+
+	var b strings.Builder
+
+	for _, c := range s.Comments {
+		fmt.Fprintf(&b, "REM %s\n", c)
+	}
+	if s.Catalog != "" {
+		fmt.Fprintf(&b, "CATALOG %s\n", s.Catalog)
+	}
+	if s.Performer != "" {
+		fmt.Fprintf(&b, "PERFORMER %s\n", quote(s.Performer))
+	}
+	if s.Title != "" {
+		fmt.Fprintf(&b, "TITLE %s\n", quote(s.Title))
+	}
+
+	file := ""
+	for i, t := range s.Tracks {
+		if i == 0 || t.File != file {
+			file = t.File
+			fmt.Fprintf(&b, "FILE %s %s\n", quote(t.File), t.FileType)
+		}
+		fmt.Fprintf(&b, "  TRACK %02d %s\n", t.Number, t.Type)
+		if t.Title != "" {
+			fmt.Fprintf(&b, "    TITLE %s\n", quote(t.Title))
+		}
+		if t.Performer != "" {
+			fmt.Fprintf(&b, "    PERFORMER %s\n", quote(t.Performer))
+		}
+		if t.ISRC != "" {
+			fmt.Fprintf(&b, "    ISRC %s\n", t.ISRC)
+		}
+		for _, idx := range t.Indexes {
+			fmt.Fprintf(&b, "    INDEX %02d %s\n", idx.Number, formatFrames(idx.Frames))
+		}
+	}
+
+	return b.String()
+}
+
+// WriteTo writes s in CUE sheet syntax to w.
+func (s *Sheet) WriteTo(w io.Writer) (int64, error) {
+	n, err := io.WriteString(w, s.String())
+	return int64(n), err
+}
+
+// Parse reads a CUE sheet. Commands which are not represented by Sheet and
+// Track, e.g. FLAGS or PREGAP, are ignored.
+func Parse(r io.Reader) (*Sheet, error) {
+
+	s := &Sheet{}
+	var track *Track
+	file, fileType := "", ""
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+
+		text := strings.TrimSpace(scanner.Text())
+		if line == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+		if text == "" {
+			continue
+		}
+
+		cmd, rest, _ := strings.Cut(text, " ")
+		rest = strings.TrimSpace(rest)
+		args := splitArgs(rest)
+
+		switch strings.ToUpper(cmd) {
+		case "REM":
+			s.Comments = append(s.Comments, rest)
+		case "CATALOG":
+			s.Catalog = rest
+		case "PERFORMER", "TITLE":
+			if len(args) == 0 {
+				return nil, fmt.Errorf("line %d: %s without value", line, cmd)
+			}
+			setText(s, track, strings.ToUpper(cmd), args[0])
+		case "FILE":
+			if len(args) < 1 {
+				return nil, fmt.Errorf("line %d: FILE without name", line)
+			}
+			file, fileType = args[0], ""
+			if len(args) > 1 {
+				fileType = args[1]
+			}
+		case "TRACK":
+			if len(args) != 2 {
+				return nil, fmt.Errorf("line %d: invalid TRACK %q", line, rest)
+			}
+			n, err := strconv.Atoi(args[0])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid track number %q", line, args[0])
+			}
+			track = &Track{File: file, FileType: fileType, Number: n, Type: args[1]}
+			s.Tracks = append(s.Tracks, track)
+		case "ISRC":
+			if track == nil {
+				return nil, fmt.Errorf("line %d: ISRC outside of TRACK", line)
+			}
+			track.ISRC = rest
+		case "INDEX":
+			if track == nil {
+				return nil, fmt.Errorf("line %d: INDEX outside of TRACK", line)
+			}
+			if len(args) != 2 {
+				return nil, fmt.Errorf("line %d: invalid INDEX %q", line, rest)
+			}
+			n, err := strconv.Atoi(args[0])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid index number %q", line, args[0])
+			}
+			frames, err := parseFrames(args[1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", line, err)
+			}
+			track.Indexes = append(track.Indexes, Index{Number: n, Frames: frames})
+		}
+	}
+
+	return s, scanner.Err()
+}
+
+// setText sets PERFORMER or TITLE of track or, before the first track, of s.
+func setText(s *Sheet, track *Track, cmd, value string) {
+	switch {
+	case track == nil && cmd == "PERFORMER":
+		s.Performer = value
+	case track == nil:
+		s.Title = value
+	case cmd == "PERFORMER":
+		track.Performer = value
+	default:
+		track.Title = value
+	}
+}
+
+// splitArgs splits the arguments of a command at spaces outside of double
+// quotes and removes the quotes.
+func splitArgs(s string) []string {
+
+	var args []string
+	var b strings.Builder
+	quoted, inArg := false, false
+
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			inArg = true
+		case r == ' ' && !quoted:
+			if inArg {
+				args = append(args, b.String())
+				b.Reset()
+				inArg = false
+			}
+		default:
+			b.WriteRune(r)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, b.String())
+	}
+	return args
+}
+
+// quote returns s as quoted CUE string. CUE has no escaping, so double quotes
+// in s are replaced by single quotes.
+func quote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "'") + `"`
+}
+
+// formatFrames returns frames as mm:ss:ff.
+func formatFrames(frames int) string {
+	return fmt.Sprintf("%02d:%02d:%02d",
+		frames/(60*FramesPerSecond), frames/FramesPerSecond%60, frames%FramesPerSecond)
+}
+
+// parseFrames parses a position in mm:ss:ff format.
+func parseFrames(s string) (int, error) {
+
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("invalid position %q", s)
+	}
+
+	var v [3]int
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid position %q", s)
+		}
+		v[i] = n
+	}
+	if v[1] >= 60 || v[2] >= FramesPerSecond {
+		return 0, fmt.Errorf("invalid position %q", s)
+	}
+
+	return (v[0]*60+v[1])*FramesPerSecond + v[2], nil
+}
@@ -0,0 +1,227 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package cuesheet
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/michiwend/gomusicbrainz"
+)
+
+func loadRelease(t *testing.T) *gomusicbrainz.Release {
+	data, err := os.ReadFile("testdata/release.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var release gomusicbrainz.Release
+	if err := gomusicbrainz.UnmarshalMMD(data, &release); err != nil {
+		t.Fatal(err)
+	}
+	return &release
+}
+
+const wantSheet = `REM DATE 2003
+REM MUSICBRAINZ_ALBUMID 3f2b7c1e-8d4a-4b6f-9e21-5c7a0d9b1e42
+REM MUSICBRAINZ_DISCID PlidbyW9jZgJXSS4TYBV.J4WLUI-
+CATALOG 0021456123457
+PERFORMER "The Lanterns"
+TITLE "Signals From the Deep"
+FILE "album.wav" WAVE
+  TRACK 01 AUDIO
+    TITLE "Signals"
+    PERFORMER "The Lanterns"
+    ISRC GBAAA0300001
+    INDEX 01 00:00:00
+  TRACK 02 AUDIO
+    TITLE "Harbour Lights"
+    PERFORMER "The Lanterns feat. Kit N."
+    ISRC GBAAA0300002
+    INDEX 01 03:22:63
+  TRACK 03 AUDIO
+    TITLE "'Undertow'"
+    PERFORMER "The Lanterns"
+    INDEX 01 07:08:64
+`
+
+func TestFromRelease(t *testing.T) {
+
+	release := loadRelease(t)
+	returned := FromRelease(release, release.Mediums[0], "album.wav").String()
+
+	if returned != wantSheet {
+		t.Errorf("Got\n%s\nwant\n%s", returned, wantSheet)
+	}
+}
+
+func TestFromReleaseWithoutDisc(t *testing.T) {
+
+	release := loadRelease(t)
+	release.Mediums[0].Discs = nil
+	sheet := FromRelease(release, release.Mediums[0], "album.wav")
+
+	// 202840 ms and 226013 ms rounded to frames.
+	want := []int{0, 15213, 32164}
+	for i, track := range sheet.Tracks {
+		if track.Start() != want[i] {
+			t.Errorf("track %d starts at %d, want %d", track.Number, track.Start(), want[i])
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+
+	f, err := os.Open("testdata/rip.cue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	returned, err := Parse(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := "The Lanterns - Signals From the Deep.wav"
+	want := &Sheet{
+		Comments:  []string{"GENRE Rock", "DATE 2003", "DISCID 1E00B403"},
+		Catalog:   "0021456123457",
+		Performer: "The Lanterns",
+		Title:     "Signals From the Deep",
+		Tracks: []*Track{
+			{
+				File:      file,
+				FileType:  "WAVE",
+				Number:    1,
+				Type:      "AUDIO",
+				Title:     "Signals",
+				Performer: "The Lanterns",
+				ISRC:      "GBAAA0300001",
+				Indexes:   []Index{{Number: 1, Frames: 0}},
+			},
+			{
+				File:      file,
+				FileType:  "WAVE",
+				Number:    2,
+				Type:      "AUDIO",
+				Title:     "Harbour Lights",
+				Performer: "The Lanterns feat. Kit N.",
+				ISRC:      "GBAAA0300002",
+				Indexes:   []Index{{Number: 0, Frames: 15035}, {Number: 1, Frames: 15213}},
+			},
+			{
+				File:      file,
+				FileType:  "WAVE",
+				Number:    3,
+				Type:      "AUDIO",
+				Title:     "Undertow",
+				Performer: "The Lanterns",
+				Indexes:   []Index{{Number: 1, Frames: 32164}},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(returned, want) {
+		t.Errorf("Got %+v, want %+v", returned, want)
+	}
+}
+
+func TestParseRoundTrip(t *testing.T) {
+
+	sheet, err := Parse(strings.NewReader(wantSheet))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if returned := sheet.String(); returned != wantSheet {
+		t.Errorf("Got\n%s\nwant\n%s", returned, wantSheet)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+
+	for _, in := range []string{
+		"INDEX 01 00:00:00",
+		"FILE \"a.wav\" WAVE\nTRACK one AUDIO",
+		"FILE \"a.wav\" WAVE\nTRACK 01 AUDIO\nINDEX 01 00:00:75",
+		"FILE \"a.wav\" WAVE\nTRACK 01 AUDIO\nINDEX 01 00:00",
+		"TITLE",
+	} {
+		if _, err := Parse(strings.NewReader(in)); err == nil {
+			t.Errorf("Parse(%q): no error", in)
+		}
+	}
+}
+
+func TestSheetTOC(t *testing.T) {
+
+	f, err := os.Open("testdata/rip.cue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	sheet, err := Parse(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	toc, err := sheet.TOC(46442)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := gomusicbrainz.TOC{
+		FirstTrack: 1,
+		LastTrack:  3,
+		LeadOut:    46592,
+		Offsets:    []int{150, 15363, 32314},
+	}
+	if !reflect.DeepEqual(toc, want) {
+		t.Errorf("Got %+v, want %+v", toc, want)
+	}
+	if id := toc.DiscID(); id != "PlidbyW9jZgJXSS4TYBV.J4WLUI-" {
+		t.Errorf("Got disc ID %q", id)
+	}
+}
+
+func TestSheetTOCErrors(t *testing.T) {
+
+	for _, in := range []string{
+		"FILE \"a.wav\" WAVE\nTRACK 01 AUDIO\nINDEX 01 00:00:00\nFILE \"b.wav\" WAVE\nTRACK 02 AUDIO\nINDEX 01 00:00:00",
+		"FILE \"a.wav\" WAVE\nTRACK 01 AUDIO\nINDEX 00 00:00:00",
+		"FILE \"a.bin\" BINARY\nTRACK 01 MODE1/2352\nINDEX 01 00:00:00",
+	} {
+		sheet, err := Parse(strings.NewReader(in))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := sheet.TOC(10000); err == nil {
+			t.Errorf("TOC of %q: no error", in)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+﻿REM GENRE Rock
+REM DATE 2003
+REM DISCID 1E00B403
+CATALOG 0021456123457
+PERFORMER "The Lanterns"
+TITLE "Signals From the Deep"
+FILE "The Lanterns - Signals From the Deep.wav" WAVE
+  TRACK 01 AUDIO
+    TITLE "Signals"
+    PERFORMER "The Lanterns"
+    ISRC GBAAA0300001
+    INDEX 01 00:00:00
+  TRACK 02 AUDIO
+    TITLE "Harbour Lights"
+    PERFORMER "The Lanterns feat. Kit N."
+    FLAGS DCP
+    ISRC GBAAA0300002
+    INDEX 00 03:20:35
+    INDEX 01 03:22:63
+  TRACK 03 AUDIO
+    TITLE "Undertow"
+    PERFORMER "The Lanterns"
+    INDEX 01 07:08:64
@@ -0,0 +1,218 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// discIDEncoding is the base64 variant of disc IDs, which replaces
+// characters that are not URL safe.
+var discIDEncoding = base64.NewEncoding(
+	"ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789._",
+).WithPadding('-')
+
+// TOC is the table of contents of an audio CD. Offsets holds the start
+// sectors of the tracks FirstTrack to LastTrack and LeadOut the sector of the
+// lead-out, i.e. the total number of sectors. Sector values include the two
+// seconds (150 sectors) pregap of the first track, as reported by CD drives.
+// For enhanced CDs only the audio tracks belong to the TOC and LeadOut is the
+// start of the data track minus 11400 sectors.
+type TOC struct {
+	FirstTrack int
+	LastTrack  int
+	LeadOut    int
+	Offsets    []int
+}
+
+// ParseTOC parses a TOC in the format of the toc parameter of disc ID
+// lookups: first track, last track, lead-out and the track offsets separated
+// by spaces or "+", e.g. "1 2 40000 150 20000".
+func ParseTOC(s string) (TOC, error) {
+
+	fields := strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == '+' })
+	if len(fields) < 4 {
+		return TOC{}, fmt.Errorf("invalid TOC %q: too few values", s)
+	}
+
+	values := make([]int, len(fields))
+	for i, f := range fields {
+		v, err := strconv.Atoi(f)
+		if err != nil {
+			return TOC{}, fmt.Errorf("invalid TOC %q: %v", s, err)
+		}
+		values[i] = v
+	}
+
+	toc := TOC{
+		FirstTrack: values[0],
+		LastTrack:  values[1],
+		LeadOut:    values[2],
+		Offsets:    values[3:],
+	}
+	return toc, toc.Validate()
+}
+
+// Validate returns an error if t is not a possible TOC of an audio CD.
+func (t TOC) Validate() error {
+
+	if t.FirstTrack < 1 || t.LastTrack > 99 || t.FirstTrack > t.LastTrack {
+		return fmt.Errorf("invalid TOC: track numbers %d to %d", t.FirstTrack, t.LastTrack)
+	}
+	if len(t.Offsets) != t.LastTrack-t.FirstTrack+1 {
+		return fmt.Errorf("invalid TOC: %d offsets for %d tracks",
+			len(t.Offsets), t.LastTrack-t.FirstTrack+1)
+	}
+	prev := 0
+	for _, o := range t.Offsets {
+		if o < prev {
+			return errors.New("invalid TOC: offsets not ascending.")
+		}
+		prev = o
+	}
+	if t.LeadOut <= prev {
+		return errors.New("invalid TOC: lead-out before last track.")
+	}
+	return nil
+}
+
+// String returns t in the format of the toc parameter of disc ID lookups,
+// e.g. "1 2 40000 150 20000".
+func (t TOC) String() string {
+	values := []string{
+		strconv.Itoa(t.FirstTrack),
+		strconv.Itoa(t.LastTrack),
+		strconv.Itoa(t.LeadOut),
+	}
+	for _, o := range t.Offsets {
+		values = append(values, strconv.Itoa(o))
+	}
+	return strings.Join(values, " ")
+}
+
+// DiscID returns the MusicBrainz disc ID of t as described at
+// https://musicbrainz.org/doc/Disc_ID_Calculation
+func (t TOC) DiscID() string {
+
+	h := sha1.New()
+	fmt.Fprintf(h, "%02X%02X%08X", t.FirstTrack, t.LastTrack, t.LeadOut)
+	for i := 1; i <= 99; i++ {
+		offset := 0
+		if n := i - t.FirstTrack; n >= 0 && n < len(t.Offsets) {
+			offset = t.Offsets[n]
+		}
+		fmt.Fprintf(h, "%08X", offset)
+	}
+
+	return discIDEncoding.EncodeToString(h.Sum(nil))
+}
+
+// TOC returns the TOC of d, which starts with track 1.
+func (d *Disc) TOC() TOC {
+	return TOC{
+		FirstTrack: 1,
+		LastTrack:  len(d.Offsets),
+		LeadOut:    d.Sectors,
+		Offsets:    d.Offsets,
+	}
+}
+
+// validDiscID reports whether id has the length and alphabet of a disc ID,
+// which is base64 with "._-" instead of "+/=".
+func validDiscID(id string) bool {
+
+	if len(id) != 28 {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+		case r == '.', r == '_', r == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// DiscIDResponse is the response type returned by LookupDiscID. Disc holds
+// the disc with the requested ID and the releases it is attached to. If no
+// such disc exists, Releases holds the releases with a medium matching the
+// TOC instead. CDStub is set if the disc ID is only known from a CD stub.
+type DiscIDResponse struct {
+	Disc     *Disc
+	Releases []*Release
+	CDStub   *CDStub
+}
+
+// LookupDiscID performs a disc ID lookup request, e.g. with the disc ID of a
+// ripped CD. If toc is not nil, releases with a matching TOC are returned if
+// the disc ID is unknown. inc params like "recordings" apply to the releases.
+func (c *WS2Client) LookupDiscID(discID string, toc *TOC, inc ...string) (*DiscIDResponse, error) {
+
+	if !validDiscID(discID) {
+		return nil, fmt.Errorf("invalid disc ID %q", discID)
+	}
+
+	params := encodeInc(inc)
+	if toc != nil {
+		if params == nil {
+			params = url.Values{}
+		}
+		params.Set("toc", toc.String())
+	}
+
+	var result struct {
+		Disc *struct {
+			Disc
+			Releases []*Release `xml:"release-list>release"`
+		} `xml:"disc"`
+		Releases []*Release `xml:"release-list>release"`
+		CDStub   *CDStub    `xml:"cdstub"`
+	}
+
+	err := c.getRequestContext(context.Background(), &result, params, "/discid/"+discID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &DiscIDResponse{
+		Releases: result.Releases,
+		CDStub:   result.CDStub,
+	}
+	if result.Disc != nil {
+		resp.Disc = &result.Disc.Disc
+		resp.Releases = result.Disc.Releases
+	}
+	return resp, nil
+}
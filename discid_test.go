@@ -0,0 +1,186 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"net/http"
+	"path"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testTOC = TOC{
+	FirstTrack: 1,
+	LastTrack:  6,
+	LeadOut:    95462,
+	Offsets:    []int{150, 15363, 32314, 46592, 63414, 80489},
+}
+
+func TestDiscID(t *testing.T) {
+
+	// example of https://musicbrainz.org/doc/Disc_ID_Calculation
+	if id := testTOC.DiscID(); id != "49HHV7Eb8UKF3aQiNmu1GR8vKTY-" {
+		t.Errorf("got disc ID %s", id)
+	}
+
+	toc, err := ParseTOC("1+6+95462+150+15363+32314+46592+63414+80489")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(toc, testTOC) {
+		t.Error(requestDiff(testTOC, toc))
+	}
+	if s := toc.String(); s != "1 6 95462 150 15363 32314 46592 63414 80489" {
+		t.Errorf("got TOC string %q", s)
+	}
+
+	for _, s := range []string{
+		"",
+		"1 6 95462",
+		"1 2 95462 150",
+		"1 2 95462 15363 150",
+		"1 2 15000 150 15363",
+		"0 1 95462 150",
+		"1 x 95462 150",
+	} {
+		if _, err := ParseTOC(s); err == nil {
+			t.Errorf("ParseTOC(%q) succeeded, want error", s)
+		}
+	}
+}
+
+func TestLookupDiscID(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+
+	var query string
+	mux.HandleFunc("/discid/49HHV7Eb8UKF3aQiNmu1GR8vKTY-", func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		http.ServeFile(w, r, path.Join("./testdata", "LookupDiscID.xml"))
+	})
+
+	returned, err := client.LookupDiscID(testTOC.DiscID(), nil, "recordings")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query != "inc=recordings" {
+		t.Errorf("got query %q", query)
+	}
+
+	want := &DiscIDResponse{
+		Disc: &Disc{
+			ID:      "49HHV7Eb8UKF3aQiNmu1GR8vKTY-",
+			Sectors: 95462,
+			Offsets: []int{150, 15363, 32314, 46592, 63414, 80489},
+		},
+		Releases: []*Release{
+			{
+				ID:          "3f2b7c1e-8d4a-4b6f-9e21-5c7a0d9b1e42",
+				Title:       "Signals From the Deep",
+				Status:      ReleaseStatusOfficial,
+				StatusID:    "4e304316-386d-3409-af2e-78857eec5cfe",
+				Date:        BrainzTime{Time: time.Date(2003, 5, 12, 0, 0, 0, 0, time.UTC), Accuracy: Day},
+				CountryCode: "GB",
+				Barcode:     "5021456123451",
+				Mediums: []*Medium{
+					{
+						Position: 1,
+						Format:   "CD",
+						FormatID: "9712d52a-4509-3d4b-a1a2-67c88c643e31",
+						Discs: []*Disc{
+							{ID: "49HHV7Eb8UKF3aQiNmu1GR8vKTY-", Sectors: 95462},
+						},
+						DiscCount:  1,
+						TrackCount: 6,
+					},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(returned, want) {
+		t.Error(requestDiff(want, returned))
+	}
+
+	if !reflect.DeepEqual(returned.Disc.TOC(), testTOC) {
+		t.Error(requestDiff(testTOC, returned.Disc.TOC()))
+	}
+
+	if _, err := client.LookupDiscID("invalid", nil); err == nil {
+		t.Error("expected error for invalid disc ID")
+	}
+}
+
+func TestLookupDiscIDFuzzy(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+
+	var toc string
+	mux.HandleFunc("/discid/49HHV7Eb8UKF3aQiNmu1GR8vKTY-", func(w http.ResponseWriter, r *http.Request) {
+		toc = r.URL.Query().Get("toc")
+		http.ServeFile(w, r, path.Join("./testdata", "LookupDiscIDFuzzy.xml"))
+	})
+
+	returned, err := client.LookupDiscID(testTOC.DiscID(), &testTOC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if toc != testTOC.String() {
+		t.Errorf("got toc %q", toc)
+	}
+	if returned.Disc != nil || len(returned.Releases) != 1 ||
+		returned.Releases[0].ID != "8a61d0f5-2c3e-4f7b-b1d9-0e4c6a2f7b13" {
+		t.Errorf("unexpected response %+v", returned)
+	}
+}
+
+func TestLookupDiscIDInvalid(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+	})
+
+	for _, id := range []string{
+		"",
+		"49HHV7Eb8UKF3aQiNmu1GR8vKTY",
+		"49HHV7Eb8UKF3aQiNmu1GR8vKTY--",
+		"../../artist/49HHV7Eb8UKF3aQ",
+		"49HHV7Eb8UKF3aQiNmu1GR8v/../",
+		"49HHV7Eb8UKF3aQiNmu1GR?inc=x",
+		"49HHV7Eb8UKF3aQiNmu1GR8vKT;x",
+		"49HHV7Eb8UKF3aQiNmu1GR8vKT%2",
+		"49HHV7Eb8UKF3aQiNmu1GR8vKTé",
+	} {
+		if _, err := client.LookupDiscID(id, nil); err == nil {
+			t.Errorf("LookupDiscID(%q): expected error", id)
+		}
+	}
+}
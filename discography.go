@@ -0,0 +1,257 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// browseLimit is the maximum number of entities per browse request.
+const browseLimit = 100
+
+// DiscographyOptions filters the release groups and releases returned by
+// Discography and configures the choice of the representative release of each
+// release group. The zero value includes everything.
+type DiscographyOptions struct {
+	// PrimaryTypes of the included release groups, e.g. Album and EP.
+	PrimaryTypes []ReleaseGroupType
+
+	// SecondaryTypes, if set, restricts the result to release groups with at
+	// least one of these secondary types, e.g. "Live".
+	SecondaryTypes []string
+
+	// ExcludeSecondaryTypes excludes release groups with any of these
+	// secondary types, e.g. "Compilation" and "Live" for studio albums only.
+	ExcludeSecondaryTypes []string
+
+	// Statuses of the included releases, e.g. Official. Release groups
+	// without such releases are omitted.
+	Statuses []ReleaseStatus
+
+	// Selector chooses the representative release of each release group,
+	// by default the earliest one.
+	Selector *ReleaseSelector
+}
+
+// DiscographyEntry is a release group of a discography.
+type DiscographyEntry struct {
+	// ReleaseGroup with its Releases which match the DiscographyOptions.
+	ReleaseGroup *ReleaseGroup
+
+	// Release is the representative release of the group, nil if the
+	// release group has no releases.
+	Release *Release
+}
+
+// Discography returns the release groups of an artist which match opts,
+// sorted by their first release date. The releases of each group are browsed
+// with their mediums and grouped under the release group, the one preferred
+// by opts.Selector is chosen as representative release. opts may be nil.
+func (c *WS2Client) Discography(ctx context.Context, artist MBID, opts *DiscographyOptions) ([]*DiscographyEntry, error) {
+
+	if opts == nil {
+		opts = &DiscographyOptions{}
+	}
+	artist, err := ParseMBID(string(artist))
+	if err != nil {
+		return nil, err
+	}
+
+	filter := url.Values{"artist": {string(artist)}}
+	if len(opts.PrimaryTypes) > 0 {
+		types := make([]string, len(opts.PrimaryTypes))
+		for i, t := range opts.PrimaryTypes {
+			types[i] = strings.ToLower(string(t))
+		}
+		filter.Set("type", strings.Join(types, "|"))
+	}
+
+	groups, err := browseAll[*ReleaseGroup](ctx, c, filter, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[MBID]*ReleaseGroup)
+	for _, rg := range groups {
+		if opts.includesGroup(rg) {
+			rg.Releases = nil
+			byID[rg.ID] = rg
+		}
+	}
+
+	// Statuses filter the releases, the type filter must not be applied to
+	// releases of groups which are credited differently.
+	filter.Del("type")
+	if len(opts.Statuses) > 0 {
+		statuses := make([]string, len(opts.Statuses))
+		for i, s := range opts.Statuses {
+			statuses[i] = strings.ToLower(string(s))
+		}
+		filter.Set("status", strings.Join(statuses, "|"))
+	}
+
+	releases, err := browseAll[*Release](ctx, c, filter, []string{"release-groups", "media"})
+	if err != nil {
+		return nil, err
+	}
+	opts.groupReleases(byID, releases)
+
+	// Releases of a release group can be credited to other artists than the
+	// group itself, e.g. "A & B", so they are browsed separately.
+	filter.Del("artist")
+	for _, rg := range groups {
+		if byID[rg.ID] == nil || len(rg.Releases) > 0 {
+			continue
+		}
+		filter.Set("release-group", string(rg.ID))
+		releases, err := browseAll[*Release](ctx, c, filter, []string{"media"})
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range releases {
+			r.ReleaseGroup.ID = rg.ID
+		}
+		opts.groupReleases(byID, releases)
+	}
+
+	var entries []*DiscographyEntry
+	for _, rg := range groups {
+		if byID[rg.ID] == nil || len(opts.Statuses) > 0 && len(rg.Releases) == 0 {
+			continue
+		}
+		entries = append(entries, &DiscographyEntry{
+			ReleaseGroup: rg,
+			Release:      rg.PreferredRelease(opts.Selector),
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i].date(), entries[j].date()
+		switch {
+		case a.IsZero() != b.IsZero():
+			return b.IsZero()
+		case !a.Time.Equal(b.Time):
+			return a.Time.Before(b.Time)
+		case entries[i].ReleaseGroup.Title != entries[j].ReleaseGroup.Title:
+			return entries[i].ReleaseGroup.Title < entries[j].ReleaseGroup.Title
+		}
+		return entries[i].ReleaseGroup.ID < entries[j].ReleaseGroup.ID
+	})
+
+	return entries, nil
+}
+
+// date returns the first release date of the release group or, if that is
+// unknown, the earliest date of its releases.
+func (e *DiscographyEntry) date() BrainzTime {
+	if d := e.ReleaseGroup.FirstReleaseDate; !d.IsZero() {
+		return d
+	}
+	var d BrainzTime
+	for _, r := range e.ReleaseGroup.Releases {
+		if !r.Date.IsZero() && (d.IsZero() || r.Date.Time.Before(d.Time)) {
+			d = r.Date
+		}
+	}
+	return d
+}
+
+// includesGroup reports whether rg matches the type filters of o.
+func (o *DiscographyOptions) includesGroup(rg *ReleaseGroup) bool {
+
+	if len(o.PrimaryTypes) > 0 {
+		found := false
+		for _, t := range o.PrimaryTypes {
+			found = found || strings.EqualFold(string(t), string(rg.PrimaryType))
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(o.SecondaryTypes) > 0 {
+		found := false
+		for _, t := range o.SecondaryTypes {
+			found = found || rg.HasSecondaryType(t)
+		}
+		if !found {
+			return false
+		}
+	}
+
+	for _, t := range o.ExcludeSecondaryTypes {
+		if rg.HasSecondaryType(t) {
+			return false
+		}
+	}
+	return true
+}
+
+// groupReleases appends the releases matching the Statuses of o to their
+// release groups in byID.
+func (o *DiscographyOptions) groupReleases(byID map[MBID]*ReleaseGroup, releases []*Release) {
+	for _, r := range releases {
+		rg := byID[r.ReleaseGroup.ID]
+		if rg == nil || !o.includesStatus(r.Status) {
+			continue
+		}
+		rg.Releases = append(rg.Releases, r)
+	}
+}
+
+func (o *DiscographyOptions) includesStatus(s ReleaseStatus) bool {
+	if len(o.Statuses) == 0 {
+		return true
+	}
+	for _, v := range o.Statuses {
+		if strings.EqualFold(string(v), string(s)) {
+			return true
+		}
+	}
+	return false
+}
+
+// browseAll pages through all entities of a browse request.
+func browseAll[T MBSearchEntity](ctx context.Context, c *WS2Client, filter url.Values, inc []string) ([]T, error) {
+
+	endpoint := newEntity[T]().apiEndpoint()
+
+	var res []T
+	for {
+		var page SearchResponse[T]
+		if err := c.browseRequestContext(ctx, endpoint, &page, filter, browseLimit, len(res), inc); err != nil {
+			return nil, err
+		}
+		res = append(res, Entities(page.Results)...)
+
+		if len(page.Results) == 0 || len(res) >= page.Count {
+			return res, nil
+		}
+	}
+}
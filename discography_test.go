@@ -0,0 +1,218 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const discographyArtist = "6d2f0b8e-91c4-4a7d-8e35-f1a0b4c92d6e"
+
+// serveDiscographyTestFiles serves two pages of release groups, the releases
+// of the artist and the releases of two release groups.
+func serveDiscographyTestFiles(t *testing.T, status string) {
+
+	mux.HandleFunc("/release-group", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("artist") != discographyArtist || q.Get("limit") != "100" {
+			t.Errorf("unexpected release group browse request %s", r.URL)
+		}
+		http.ServeFile(w, r, path.Join("./testdata", "BrowseDiscographyReleaseGroups-"+q.Get("offset")+".xml"))
+	})
+
+	mux.HandleFunc("/release", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("status") != status || q.Get("type") != "" {
+			t.Errorf("unexpected release browse request %s", r.URL)
+		}
+		switch {
+		case q.Get("artist") == discographyArtist && q.Get("inc") == "release-groups+media":
+			http.ServeFile(w, r, path.Join("./testdata", "BrowseDiscographyReleases.xml"))
+		case q.Get("release-group") != "" && q.Get("inc") == "media":
+			http.ServeFile(w, r, path.Join("./testdata", "BrowseDiscographyReleases-"+q.Get("release-group")+".xml"))
+		default:
+			t.Errorf("unexpected release browse request %s", r.URL)
+			http.NotFound(w, r)
+		}
+	})
+}
+
+type discographyEntryIDs struct {
+	ReleaseGroup MBID
+	Release      MBID
+	Releases     int
+}
+
+func discographyIDs(entries []*DiscographyEntry) []discographyEntryIDs {
+	var res []discographyEntryIDs
+	for _, e := range entries {
+		ids := discographyEntryIDs{
+			ReleaseGroup: e.ReleaseGroup.ID,
+			Releases:     len(e.ReleaseGroup.Releases),
+		}
+		if e.Release != nil {
+			ids.Release = e.Release.ID
+		}
+		res = append(res, ids)
+	}
+	return res
+}
+
+func TestDiscography(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+	serveDiscographyTestFiles(t, "")
+
+	entries, err := client.Discography(context.Background(), discographyArtist, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []discographyEntryIDs{
+		{"f68626e2-cbf6-415e-8502-4a5a87814144", "68e14f16-309c-4fe6-ba2c-8cdb1cf603f4", 1}, // 2001
+		{"bd9e1c28-4329-4391-8bec-a3216a43cd46", "fcc5405e-8ff1-4ab9-8a21-62a5d92bf4ff", 1}, // 2002-10-01
+		// earliest releases on the same day, the lower MBID wins
+		{"ca543ca6-6d76-4190-bb35-855854396b21", "5ad85713-f4af-4f58-913d-d9825ad56e28", 3}, // 2003-05-12
+		// no first release date, dated by its release
+		{"ba78a126-e470-4ed5-8933-f275385e670c", "5fffff5c-e2aa-4ae8-95d6-849625be10df", 1}, // 2004-03-15
+		{"b180a8f6-54e5-4226-9d9d-0df8d40f1880", "7e76e889-5cf6-4d28-8472-87974273ba2e", 1}, // 2005
+	}
+
+	if returned := discographyIDs(entries); !reflect.DeepEqual(returned, want) {
+		t.Error(requestDiff(&want, &returned))
+	}
+
+	// the artist MBID is normalized before browsing
+	upper := MBID(" " + strings.ToUpper(discographyArtist) + " ")
+	if _, err := client.Discography(context.Background(), upper, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDiscographyOptions(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+	serveDiscographyTestFiles(t, "official")
+
+	entries, err := client.Discography(context.Background(), discographyArtist, &DiscographyOptions{
+		PrimaryTypes:          []ReleaseGroupType{ReleaseGroupTypeAlbum, ReleaseGroupTypeEP},
+		ExcludeSecondaryTypes: []string{"live"},
+		Statuses:              []ReleaseStatus{ReleaseStatusOfficial},
+		Selector: &ReleaseSelector{
+			Countries: []string{"US", "GB"},
+			Formats:   []string{"CD"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the single and the live album are filtered by type, the demos have
+	// no official releases
+	want := []discographyEntryIDs{
+		{"ca543ca6-6d76-4190-bb35-855854396b21", "dfde74a2-211e-45e8-9b5f-3b462ba66555", 3},
+		{"ba78a126-e470-4ed5-8933-f275385e670c", "5fffff5c-e2aa-4ae8-95d6-849625be10df", 1},
+	}
+
+	if returned := discographyIDs(entries); !reflect.DeepEqual(returned, want) {
+		t.Error(requestDiff(&want, &returned))
+	}
+}
+
+func TestDiscographySecondaryTypes(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+	serveDiscographyTestFiles(t, "")
+
+	entries, err := client.Discography(context.Background(), discographyArtist, &DiscographyOptions{
+		SecondaryTypes: []string{"Live"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].ReleaseGroup.ID != "b180a8f6-54e5-4226-9d9d-0df8d40f1880" {
+		t.Errorf("got %+v, want the live album only", discographyIDs(entries))
+	}
+
+}
+
+func TestDiscographyAuthCanceled(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+
+	started := make(chan struct{})
+	serveDigestAuth("/release-group", t, func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(10 * time.Second):
+		}
+	})
+
+	rec := &recordingHook{}
+	client.Hooks = []RequestHook{rec}
+	client.SetCredentials(testUsername, testPassword)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := client.Discography(ctx, discographyArtist, nil)
+		done <- err
+	}()
+
+	<-started
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("got %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Discography was not canceled")
+	}
+
+	if len(rec.infos) != 1 || rec.infos[0].Endpoint != "/release-group" || rec.infos[0].Err == nil {
+		t.Errorf("unexpected hook calls %+v", rec.infos)
+	}
+}
+
+func TestDiscographyInvalidMBID(t *testing.T) {
+	if _, err := client.Discography(context.Background(), "not-an-mbid", nil); err == nil {
+		t.Error("no error for invalid MBID")
+	}
+}
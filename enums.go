@@ -0,0 +1,285 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import "strings"
+
+// The typed enumerations in this file are strings which are normalized to the
+// canonical MusicBrainz spelling when they are decoded or parsed, so values can
+// be compared with the provided constants regardless of the case used by WS2
+// e.g. "official" and "Official" both decode to ReleaseStatusOfficial. Unknown
+// values are preserved as they are and can be detected with IsKnown.
+//
+// All enumerations implement encoding.TextMarshaler and
+// encoding.TextUnmarshaler, so they are handled by encoding/xml (as element
+// and attribute) and encoding/json alike.
+
+// enumSet holds the canonical spellings of an enumeration.
+type enumSet []string
+
+// parse returns the canonical spelling of s if it case-insensitively matches
+// a known value or s otherwise.
+func (e enumSet) parse(s string) string {
+	for _, v := range e {
+		if strings.EqualFold(v, s) {
+			return v
+		}
+	}
+	return s
+}
+
+func (e enumSet) contains(s string) bool {
+	for _, v := range e {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ArtistType is the type of an artist. See
+// https://musicbrainz.org/doc/Artist#Type
+type ArtistType string
+
+const (
+	ArtistTypePerson    ArtistType = "Person"
+	ArtistTypeGroup     ArtistType = "Group"
+	ArtistTypeOrchestra ArtistType = "Orchestra"
+	ArtistTypeChoir     ArtistType = "Choir"
+	ArtistTypeCharacter ArtistType = "Character"
+	ArtistTypeOther     ArtistType = "Other"
+)
+
+var artistTypes = enumSet{"Person", "Group", "Orchestra", "Choir", "Character", "Other"}
+
+// ParseArtistType returns the ArtistType for s. The comparison is
+// case-insensitive.
+func ParseArtistType(s string) ArtistType { return ArtistType(artistTypes.parse(s)) }
+
+func (t ArtistType) String() string { return string(t) }
+
+// IsKnown reports whether t is one of the ArtistType constants.
+func (t ArtistType) IsKnown() bool { return artistTypes.contains(string(t)) }
+
+func (t ArtistType) MarshalText() ([]byte, error) { return []byte(t), nil }
+
+func (t *ArtistType) UnmarshalText(text []byte) error {
+	*t = ParseArtistType(string(text))
+	return nil
+}
+
+// Gender is the gender of a person or character artist. See
+// https://musicbrainz.org/doc/Artist#Gender
+type Gender string
+
+const (
+	GenderMale          Gender = "Male"
+	GenderFemale        Gender = "Female"
+	GenderNonBinary     Gender = "Non-binary"
+	GenderOther         Gender = "Other"
+	GenderNotApplicable Gender = "Not applicable"
+)
+
+var genders = enumSet{"Male", "Female", "Non-binary", "Other", "Not applicable"}
+
+// ParseGender returns the Gender for s. The comparison is case-insensitive.
+func ParseGender(s string) Gender { return Gender(genders.parse(s)) }
+
+func (g Gender) String() string { return string(g) }
+
+// IsKnown reports whether g is one of the Gender constants.
+func (g Gender) IsKnown() bool { return genders.contains(string(g)) }
+
+func (g Gender) MarshalText() ([]byte, error) { return []byte(g), nil }
+
+func (g *Gender) UnmarshalText(text []byte) error {
+	*g = ParseGender(string(text))
+	return nil
+}
+
+// ReleaseStatus is the status of a release. See
+// https://musicbrainz.org/doc/Release#Status
+type ReleaseStatus string
+
+const (
+	ReleaseStatusOfficial      ReleaseStatus = "Official"
+	ReleaseStatusPromotion     ReleaseStatus = "Promotion"
+	ReleaseStatusBootleg       ReleaseStatus = "Bootleg"
+	ReleaseStatusPseudoRelease ReleaseStatus = "Pseudo-Release"
+	ReleaseStatusWithdrawn     ReleaseStatus = "Withdrawn"
+	ReleaseStatusCancelled     ReleaseStatus = "Cancelled"
+)
+
+var releaseStatuses = enumSet{"Official", "Promotion", "Bootleg", "Pseudo-Release", "Withdrawn", "Cancelled"}
+
+// ParseReleaseStatus returns the ReleaseStatus for s. The comparison is
+// case-insensitive.
+func ParseReleaseStatus(s string) ReleaseStatus {
+	return ReleaseStatus(releaseStatuses.parse(s))
+}
+
+func (s ReleaseStatus) String() string { return string(s) }
+
+// IsKnown reports whether s is one of the ReleaseStatus constants.
+func (s ReleaseStatus) IsKnown() bool { return releaseStatuses.contains(string(s)) }
+
+func (s ReleaseStatus) MarshalText() ([]byte, error) { return []byte(s), nil }
+
+func (s *ReleaseStatus) UnmarshalText(text []byte) error {
+	*s = ParseReleaseStatus(string(text))
+	return nil
+}
+
+// ReleaseGroupType is the primary type of a release group. See
+// https://musicbrainz.org/doc/Release_Group/Type
+type ReleaseGroupType string
+
+const (
+	ReleaseGroupTypeAlbum     ReleaseGroupType = "Album"
+	ReleaseGroupTypeSingle    ReleaseGroupType = "Single"
+	ReleaseGroupTypeEP        ReleaseGroupType = "EP"
+	ReleaseGroupTypeBroadcast ReleaseGroupType = "Broadcast"
+	ReleaseGroupTypeOther     ReleaseGroupType = "Other"
+)
+
+var releaseGroupTypes = enumSet{"Album", "Single", "EP", "Broadcast", "Other"}
+
+// ParseReleaseGroupType returns the ReleaseGroupType for s. The comparison is
+// case-insensitive.
+func ParseReleaseGroupType(s string) ReleaseGroupType {
+	return ReleaseGroupType(releaseGroupTypes.parse(s))
+}
+
+func (t ReleaseGroupType) String() string { return string(t) }
+
+// IsKnown reports whether t is one of the ReleaseGroupType constants.
+func (t ReleaseGroupType) IsKnown() bool { return releaseGroupTypes.contains(string(t)) }
+
+func (t ReleaseGroupType) MarshalText() ([]byte, error) { return []byte(t), nil }
+
+func (t *ReleaseGroupType) UnmarshalText(text []byte) error {
+	*t = ParseReleaseGroupType(string(text))
+	return nil
+}
+
+// LabelType is the type of a label. See https://musicbrainz.org/doc/Label/Type
+type LabelType string
+
+const (
+	LabelTypeImprint       LabelType = "Imprint"
+	LabelTypeProduction    LabelType = "Production"
+	LabelTypeOriginal      LabelType = "Original Production"
+	LabelTypeBootleg       LabelType = "Bootleg Production"
+	LabelTypeReissue       LabelType = "Reissue Production"
+	LabelTypeDistributor   LabelType = "Distributor"
+	LabelTypeHolding       LabelType = "Holding"
+	LabelTypeManufacturer  LabelType = "Manufacturer"
+	LabelTypePublisher     LabelType = "Publisher"
+	LabelTypeRightsSociety LabelType = "Rights Society"
+)
+
+var labelTypes = enumSet{"Imprint", "Production", "Original Production", "Bootleg Production",
+	"Reissue Production", "Distributor", "Holding", "Manufacturer", "Publisher", "Rights Society"}
+
+// ParseLabelType returns the LabelType for s. The comparison is
+// case-insensitive.
+func ParseLabelType(s string) LabelType { return LabelType(labelTypes.parse(s)) }
+
+func (t LabelType) String() string { return string(t) }
+
+// IsKnown reports whether t is one of the LabelType constants.
+func (t LabelType) IsKnown() bool { return labelTypes.contains(string(t)) }
+
+func (t LabelType) MarshalText() ([]byte, error) { return []byte(t), nil }
+
+func (t *LabelType) UnmarshalText(text []byte) error {
+	*t = ParseLabelType(string(text))
+	return nil
+}
+
+// AreaType is the type of an area. See https://musicbrainz.org/doc/Area#Type
+type AreaType string
+
+const (
+	AreaTypeCountry      AreaType = "Country"
+	AreaTypeSubdivision  AreaType = "Subdivision"
+	AreaTypeCounty       AreaType = "County"
+	AreaTypeMunicipality AreaType = "Municipality"
+	AreaTypeCity         AreaType = "City"
+	AreaTypeDistrict     AreaType = "District"
+	AreaTypeIsland       AreaType = "Island"
+)
+
+var areaTypes = enumSet{"Country", "Subdivision", "County", "Municipality", "City", "District", "Island"}
+
+// ParseAreaType returns the AreaType for s. The comparison is
+// case-insensitive.
+func ParseAreaType(s string) AreaType { return AreaType(areaTypes.parse(s)) }
+
+func (t AreaType) String() string { return string(t) }
+
+// IsKnown reports whether t is one of the AreaType constants.
+func (t AreaType) IsKnown() bool { return areaTypes.contains(string(t)) }
+
+func (t AreaType) MarshalText() ([]byte, error) { return []byte(t), nil }
+
+func (t *AreaType) UnmarshalText(text []byte) error {
+	*t = ParseAreaType(string(text))
+	return nil
+}
+
+// PlaceType is the type of a place. See https://musicbrainz.org/doc/Place#Type
+type PlaceType string
+
+const (
+	PlaceTypeStudio                 PlaceType = "Studio"
+	PlaceTypeVenue                  PlaceType = "Venue"
+	PlaceTypeStadium                PlaceType = "Stadium"
+	PlaceTypeIndoorArena            PlaceType = "Indoor arena"
+	PlaceTypeReligiousBuilding      PlaceType = "Religious building"
+	PlaceTypeEducationalInstitution PlaceType = "Educational institution"
+	PlaceTypePressingPlant          PlaceType = "Pressing plant"
+	PlaceTypeOther                  PlaceType = "Other"
+)
+
+var placeTypes = enumSet{"Studio", "Venue", "Stadium", "Indoor arena", "Religious building",
+	"Educational institution", "Pressing plant", "Other"}
+
+// ParsePlaceType returns the PlaceType for s. The comparison is
+// case-insensitive.
+func ParsePlaceType(s string) PlaceType { return PlaceType(placeTypes.parse(s)) }
+
+func (t PlaceType) String() string { return string(t) }
+
+// IsKnown reports whether t is one of the PlaceType constants.
+func (t PlaceType) IsKnown() bool { return placeTypes.contains(string(t)) }
+
+func (t PlaceType) MarshalText() ([]byte, error) { return []byte(t), nil }
+
+func (t *PlaceType) UnmarshalText(text []byte) error {
+	*t = ParsePlaceType(string(text))
+	return nil
+}
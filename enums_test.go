@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+)
+
+func TestParseEnums(t *testing.T) {
+
+	if s := ParseReleaseStatus("pseudo-release"); s != ReleaseStatusPseudoRelease || !s.IsKnown() {
+		t.Errorf("unexpected release status %q", s)
+	}
+	if g := ParseGender("nogender"); g != "nogender" || g.IsKnown() {
+		t.Errorf("expected unknown gender to be preserved, got %q", g)
+	}
+	if at := ParseArtistType("PERSON"); at != ArtistTypePerson || at.String() != "Person" {
+		t.Errorf("unexpected artist type %q", at)
+	}
+	if pt := ParsePlaceType("indoor ARENA"); pt != PlaceTypeIndoorArena {
+		t.Errorf("unexpected place type %q", pt)
+	}
+}
+
+func TestDecodeEnums(t *testing.T) {
+
+	data := `<artist id="10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8" type="person" type-id="b6e035f4-3ce9-331c-97df-83397230b0df">
+		<name>Gopher</name>
+		<gender id="36d3d30a-839d-3eda-8cb3-29be4384e4a9">male</gender>
+		<area id="489ce91b-6658-3307-9877-795b68554c98" type="country"><name>United States</name></area>
+	</artist>`
+
+	var a Artist
+	if err := xml.Unmarshal([]byte(data), &a); err != nil {
+		t.Fatal(err)
+	}
+
+	if a.Type != ArtistTypePerson || a.TypeID != "b6e035f4-3ce9-331c-97df-83397230b0df" {
+		t.Errorf("unexpected artist type %q (%s)", a.Type, a.TypeID)
+	}
+	if a.Gender != GenderMale || a.GenderID != "36d3d30a-839d-3eda-8cb3-29be4384e4a9" {
+		t.Errorf("unexpected gender %q (%s)", a.Gender, a.GenderID)
+	}
+	if a.Area.Type != AreaTypeCountry {
+		t.Errorf("unexpected area type %q", a.Area.Type)
+	}
+
+	out, err := json.Marshal(a.Type)
+	if err != nil || string(out) != `"Person"` {
+		t.Errorf("unexpected JSON %s (%v)", out, err)
+	}
+
+	var lt LabelType
+	if err := json.Unmarshal([]byte(`"original production"`), &lt); err != nil || lt != LabelTypeOriginal {
+		t.Errorf("unexpected label type %q (%v)", lt, err)
+	}
+}
@@ -0,0 +1,135 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"context"
+	"encoding/xml"
+)
+
+// Event represents an organised event which people can attend e.g. a concert,
+// a festival or a launch event. See https://musicbrainz.org/doc/Event
+type Event struct {
+	ID             MBID               `xml:"id,attr"`
+	Type           string             `xml:"type,attr,omitempty"`
+	Name           string             `xml:"name,omitempty"`
+	Disambiguation string             `xml:"disambiguation,omitempty"`
+	Cancelled      bool               `xml:"cancelled,omitempty"`
+	Lifespan       Lifespan           `xml:"life-span"`
+	Time           string             `xml:"time,omitempty"`
+	Setlist        string             `xml:"setlist,omitempty"`
+	Aliases        []*Alias           `xml:"alias-list>alias"`
+	Tags           []*Tag             `xml:"tag-list>tag"`
+	Rating         Rating             `xml:"rating"`
+	UserRating     int                `xml:"user-rating,omitempty"`
+	Relations      TargetRelationsMap `xml:"relation-list"`
+}
+
+// MarshalXML omits the alias-list and tag-list if they are empty.
+func (mbe Event) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+
+	type event Event
+	res := struct {
+		*event
+		Aliases *xmlList[*Alias] `xml:"alias-list"`
+		Tags    *xmlList[*Tag]   `xml:"tag-list"`
+	}{
+		event:   (*event)(&mbe),
+		Aliases: newXMLList("alias", mbe.Aliases),
+		Tags:    newXMLList("tag", mbe.Tags),
+	}
+
+	return e.EncodeElement(res, start)
+}
+
+func (mbe *Event) lookupResult() interface{} {
+	return &lookupDoc{mbe}
+}
+
+func (mbe *Event) apiEndpoint() string {
+	return "/event"
+}
+
+func (mbe *Event) Id() MBID {
+	return mbe.ID
+}
+
+// LookupEvent performs an event lookup request for the given MBID.
+func (c *WS2Client) LookupEvent(id MBID, inc ...string) (*Event, error) {
+	a := &Event{ID: id}
+	err := c.Lookup(a, inc...)
+
+	return a, err
+}
+
+// SearchEvent queries MusicBrainz´ Search Server for Events.
+//
+// Possible search fields to provide in searchTerm are:
+//
+//	aid          MBID of an area related to the event
+//	alias        the aliases/misspellings for this event
+//	area         name of an area related to the event
+//	arid         MBID of an artist related to the event
+//	artist       name of an artist related to the event
+//	begin        begin date of the event
+//	comment      disambiguation comment
+//	eid          MBID of the event
+//	end          end date of the event
+//	ended        true if the event has ended
+//	event        name of the event
+//	eventaccent  name of the event with any accent characters retained
+//	pid          MBID of a place related to the event
+//	place        name of a place related to the event
+//	tag          folksonomy tag
+//	type         event type
+//
+// With no fields specified searchTerm searches the event and alias fields. For
+// more information visit
+// https://musicbrainz.org/doc/Development/XML_Web_Service/Version_2/Search#Event
+func (c *WS2Client) SearchEvent(searchTerm string, limit, offset int) (*EventSearchResponse, error) {
+
+	result, err := Search[*Event](context.Background(), c, searchTerm, limit, offset)
+
+	return &EventSearchResponse{
+		WS2ListResponse: result.WS2ListResponse,
+		Events:          Entities(result.Results),
+		Scores:          scoreMap(result.Results),
+		Results:         result.Results,
+	}, err
+}
+
+// EventSearchResponse is the response type returned by the SearchEvent method.
+type EventSearchResponse struct {
+	WS2ListResponse
+	Events  []*Event
+	Scores  ScoreMap
+	Results []Scored[*Event]
+}
+
+// ResultsWithScore returns a slice of Events with a min score.
+func (r *EventSearchResponse) ResultsWithScore(score int) []*Event {
+	return Entities(FilterByScore(r.Results, score))
+}
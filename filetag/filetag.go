@@ -0,0 +1,240 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+/*
+Package filetag maps MusicBrainz releases to the tags of audio files as written
+by MusicBrainz Picard, see https://picard-docs.musicbrainz.org/en/appendices/tag_mapping.html
+
+ForTrack returns the tags of a track as a format neutral map of Vorbis comment
+style names (e.g. MUSICBRAINZ_ALBUMID) to their values. Writing them to ID3,
+Vorbis or MP4 tags is left to a tagging library, which usually maps these names
+to the frames and atoms of its format.
+
+	release, err := client.LookupRelease(id, "recordings", "artist-credits",
+		"release-groups", "labels", "isrcs")
+	...
+	for _, medium := range release.Mediums {
+		for _, track := range medium.Tracks {
+			tags := filetag.ForTrack(release, medium, track)
+			...
+		}
+	}
+*/
+package filetag
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/michiwend/gomusicbrainz"
+)
+
+// Names of the tags set by ForTrack. Tags marked as list may have multiple
+// values.
+const (
+	Album           = "ALBUM"
+	AlbumArtist     = "ALBUMARTIST"
+	AlbumArtistSort = "ALBUMARTISTSORT"
+	Artist          = "ARTIST"
+	ArtistSort      = "ARTISTSORT"
+	Artists         = "ARTISTS" // list
+	Title           = "TITLE"
+	DiscSubtitle    = "DISCSUBTITLE"
+
+	AlbumID        = "MUSICBRAINZ_ALBUMID"
+	AlbumArtistID  = "MUSICBRAINZ_ALBUMARTISTID" // list
+	ArtistID       = "MUSICBRAINZ_ARTISTID"      // list
+	ReleaseGroupID = "MUSICBRAINZ_RELEASEGROUPID"
+	RecordingID    = "MUSICBRAINZ_TRACKID" // the recording MBID, named so by Picard
+	ReleaseTrackID = "MUSICBRAINZ_RELEASETRACKID"
+
+	ReleaseType    = "RELEASETYPE" // list
+	ReleaseStatus  = "RELEASESTATUS"
+	ReleaseCountry = "RELEASECOUNTRY"
+	Barcode        = "BARCODE"
+	ASIN           = "ASIN"
+	CatalogNumber  = "CATALOGNUMBER" // list
+	Label          = "LABEL"         // list
+	ISRC           = "ISRC"          // list
+	Media          = "MEDIA"
+	Script         = "SCRIPT"
+	Language       = "LANGUAGE"
+
+	DiscNumber   = "DISCNUMBER"
+	TotalDiscs   = "TOTALDISCS"
+	TrackNumber  = "TRACKNUMBER"
+	TotalTracks  = "TOTALTRACKS"
+	Date         = "DATE"
+	OriginalDate = "ORIGINALDATE"
+	OriginalYear = "ORIGINALYEAR"
+)
+
+// Tags maps tag names to their values. Tags without value are not set.
+type Tags map[string][]string
+
+// Get returns the first value of the tag name or "".
+func (t Tags) Get(name string) string {
+	if v := t[name]; len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// set sets the non-empty values of the tag name.
+func (t Tags) set(name string, values ...string) {
+	var vs []string
+	for _, v := range values {
+		if v != "" {
+			vs = append(vs, v)
+		}
+	}
+	if len(vs) > 0 {
+		t[name] = vs
+	}
+}
+
+// ForTrack returns the tags of track t on medium m of release r. The track
+// artist falls back to the artist of the recording and the release. Tags of
+// data which is not included in r, e.g. ISRCs if r was looked up without the
+// isrcs inc parameter, are not set.
+func ForTrack(r *gomusicbrainz.Release, m *gomusicbrainz.Medium, t *gomusicbrainz.Track) Tags {
+
+	tags := make(Tags)
+
+	tags.set(Album, r.Title)
+	tags.set(AlbumID, string(r.ID))
+	tags.set(AlbumArtist, r.ArtistCredit.Name())
+	tags.set(AlbumArtistSort, r.ArtistCredit.SortName())
+	tags.set(AlbumArtistID, artistIDs(r.ArtistCredit)...)
+
+	ac := t.ArtistCredit
+	if len(ac.NameCredits) == 0 {
+		ac = t.Recording.ArtistCredit
+	}
+	if len(ac.NameCredits) == 0 {
+		ac = r.ArtistCredit
+	}
+	tags.set(Artist, ac.Name())
+	tags.set(ArtistSort, ac.SortName())
+	tags.set(ArtistID, artistIDs(ac)...)
+	tags.set(Artists, creditedNames(ac)...)
+
+	title := t.Title
+	if title == "" {
+		title = t.Recording.Title
+	}
+	tags.set(Title, title)
+	tags.set(RecordingID, string(t.Recording.ID))
+	tags.set(ReleaseTrackID, string(t.ID))
+	tags.set(ISRC, t.Recording.ISRCs...)
+
+	rg := r.ReleaseGroup
+	tags.set(ReleaseGroupID, string(rg.ID))
+	tags.set(ReleaseType, releaseTypes(rg)...)
+	tags.set(OriginalDate, rg.FirstReleaseDate.String())
+	if !rg.FirstReleaseDate.IsZero() {
+		tags.set(OriginalYear, strconv.Itoa(rg.FirstReleaseDate.Year()))
+	}
+
+	tags.set(ReleaseStatus, strings.ToLower(string(r.Status)))
+	tags.set(ReleaseCountry, r.CountryCode)
+	tags.set(Date, r.Date.String())
+	tags.set(Barcode, r.Barcode)
+	tags.set(ASIN, r.Asin)
+	tags.set(Script, r.TextRepresentation.Script)
+	tags.set(Language, r.TextRepresentation.Language)
+
+	var labels, catnos []string
+	for _, li := range r.LabelInfos {
+		if li.Label != nil && !slices.Contains(labels, li.Label.Name) {
+			labels = append(labels, li.Label.Name)
+		}
+		if !slices.Contains(catnos, li.CatalogNumber) {
+			catnos = append(catnos, li.CatalogNumber)
+		}
+	}
+	tags.set(Label, labels...)
+	tags.set(CatalogNumber, catnos...)
+
+	tags.set(Media, m.Format)
+	tags.set(DiscSubtitle, m.Title)
+	if m.Position > 0 {
+		tags.set(DiscNumber, strconv.Itoa(m.Position))
+	}
+	if len(r.Mediums) > 0 {
+		tags.set(TotalDiscs, strconv.Itoa(len(r.Mediums)))
+	}
+	if t.Position > 0 {
+		tags.set(TrackNumber, strconv.Itoa(t.Position))
+	}
+	total := m.TrackCount
+	if total == 0 {
+		total = len(m.Tracks)
+	}
+	if total > 0 {
+		tags.set(TotalTracks, strconv.Itoa(total))
+	}
+
+	return tags
+}
+
+// artistIDs returns the MBIDs of the artists of ac.
+func artistIDs(ac gomusicbrainz.ArtistCredit) []string {
+	var ids []string
+	for _, nc := range ac.NameCredits {
+		ids = append(ids, string(nc.Artist.ID))
+	}
+	return ids
+}
+
+// creditedNames returns the names the artists of ac are credited with.
+func creditedNames(ac gomusicbrainz.ArtistCredit) []string {
+	var names []string
+	for _, nc := range ac.NameCredits {
+		if nc.Name != "" {
+			names = append(names, nc.Name)
+		} else {
+			names = append(names, nc.Artist.Name)
+		}
+	}
+	return names
+}
+
+// releaseTypes returns the lower case primary and secondary types of rg, e.g.
+// "album", "compilation".
+func releaseTypes(rg gomusicbrainz.ReleaseGroup) []string {
+
+	var types []string
+	if rg.PrimaryType != "" {
+		types = append(types, strings.ToLower(string(rg.PrimaryType)))
+	} else if rg.Type != "" {
+		types = append(types, strings.ToLower(rg.Type))
+	}
+	for _, st := range rg.SecondaryTypes {
+		types = append(types, strings.ToLower(st))
+	}
+	return types
+}
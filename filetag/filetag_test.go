@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package filetag
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/michiwend/gomusicbrainz"
+)
+
+func TestForTrack(t *testing.T) {
+
+	data, err := os.ReadFile("testdata/release.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var release gomusicbrainz.Release
+	if err := gomusicbrainz.UnmarshalMMD(data, &release); err != nil {
+		t.Fatal(err)
+	}
+
+	medium := release.Mediums[1]
+	returned := ForTrack(&release, medium, medium.Tracks[0])
+
+	want := Tags{
+		Album:           {"Signals From the Deep"},
+		AlbumArtist:     {"The Lanterns"},
+		AlbumArtistSort: {"Lanterns, The"},
+		Artist:          {"The Lanterns feat. Kit N."},
+		ArtistSort:      {"Lanterns, The feat. North, Kit"},
+		Artists:         {"The Lanterns", "Kit N."},
+		Title:           {"Harbour Lights (live)"},
+		DiscSubtitle:    {"Bonus Disc"},
+
+		AlbumID:        {"3f2b7c1e-8d4a-4b6f-9e21-5c7a0d9b1e42"},
+		AlbumArtistID:  {"6d2f0b8e-91c4-4a7d-8e35-f1a0b4c92d6e"},
+		ArtistID:       {"6d2f0b8e-91c4-4a7d-8e35-f1a0b4c92d6e", "7e3a1c9f-2b4d-4e6f-9a1c-3e5a7c9e2b4d"},
+		ReleaseGroupID: {"9b7d5f3a-1c2e-4a6b-8d0f-2e4a6c8e0a1b"},
+		RecordingID:    {"6b9d4f2a-3e8c-4dab-9f7a-1c2e3d4f5a6b"},
+		ReleaseTrackID: {"1f0e5d2b-8c3a-4b49-ad76-e2a9f3b4c5d6"},
+
+		ReleaseType:    {"album", "live"},
+		ReleaseStatus:  {"official"},
+		ReleaseCountry: {"GB"},
+		Barcode:        {"5021456123451"},
+		ASIN:           {"B0001XYZ12"},
+		CatalogNumber:  {"LAN 001", "LAN 001X"},
+		Label:          {"Harbour Records"},
+		ISRC:           {"GBAAA0300001", "GBAAA0300002"},
+		Media:          {"CD"},
+		Script:         {"Latn"},
+		Language:       {"eng"},
+
+		DiscNumber:   {"2"},
+		TotalDiscs:   {"2"},
+		TrackNumber:  {"1"},
+		TotalTracks:  {"2"},
+		Date:         {"2004-02"},
+		OriginalDate: {"2003-05-12"},
+		OriginalYear: {"2003"},
+	}
+
+	if !reflect.DeepEqual(returned, want) {
+		for name := range mergeKeys(want, returned) {
+			if !reflect.DeepEqual(returned[name], want[name]) {
+				t.Errorf("%s is %q, want %q", name, returned[name], want[name])
+			}
+		}
+	}
+}
+
+func TestForTrackFallbacks(t *testing.T) {
+
+	release := &gomusicbrainz.Release{
+		Title: "Signals From the Deep",
+		ArtistCredit: gomusicbrainz.ArtistCredit{
+			NameCredits: []gomusicbrainz.NameCredit{
+				{Artist: gomusicbrainz.Artist{ID: "6d2f0b8e-91c4-4a7d-8e35-f1a0b4c92d6e", Name: "The Lanterns"}},
+			},
+		},
+	}
+	medium := &gomusicbrainz.Medium{}
+	track := &gomusicbrainz.Track{Recording: gomusicbrainz.Recording{Title: "Signals"}}
+	medium.Tracks = []*gomusicbrainz.Track{track}
+
+	tags := ForTrack(release, medium, track)
+
+	if got := tags.Get(Artist); got != "The Lanterns" {
+		t.Errorf("artist is %q, want the release artist", got)
+	}
+	if got := tags.Get(Title); got != "Signals" {
+		t.Errorf("title is %q, want the recording title", got)
+	}
+	if got := tags.Get(TotalTracks); got != "1" {
+		t.Errorf("total tracks is %q, want 1", got)
+	}
+	for _, name := range []string{ISRC, Date, ReleaseStatus, DiscNumber, TotalDiscs, Label} {
+		if v, ok := tags[name]; ok {
+			t.Errorf("%s should not be set, got %q", name, v)
+		}
+	}
+}
+
+func mergeKeys(a, b Tags) map[string]bool {
+	keys := make(map[string]bool)
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+	return keys
+}
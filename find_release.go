@@ -0,0 +1,222 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Weights used to rank candidates of FindReleasesByBarcode and
+// FindReleasesByCatalogNumber.
+const (
+	findWeightBarcode = 100
+	findWeightCatNo   = 100
+	findWeightLabel   = 30
+	findWeightCountry = 20
+	findWeightFormat  = 20
+)
+
+// FindReleaseOptions holds preferences used to rank release candidates. All
+// fields are optional.
+type FindReleaseOptions struct {
+	Label   string // label name or MBID
+	Country string // two letter country code e.g. "GB"
+	Format  string // medium format e.g. "CD", "Vinyl"
+	Limit   int    // max. number of search results to rank (1-100, default 25)
+}
+
+// ReleaseCandidate is a release found by FindReleasesByBarcode or
+// FindReleasesByCatalogNumber. LabelInfo is the label info of the release that
+// matched the catalog number or the preferred label, it is nil if none did.
+type ReleaseCandidate struct {
+	Release     *Release
+	LabelInfo   *LabelInfo
+	Rank        int // ranking score, higher is better
+	SearchScore int
+}
+
+// NormalizeBarcode returns barcode without separators and with redundant
+// leading zeros removed, so a EAN-13 starting with 0 becomes its UPC-A
+// equivalent. An error is returned if barcode is not a valid GTIN.
+func NormalizeBarcode(barcode string) (string, error) {
+
+	n := strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, barcode)
+
+	if !ValidBarcode(n) {
+		return "", fmt.Errorf("invalid barcode %q", barcode)
+	}
+
+	// GTIN-14 and EAN-13 with leading zeros are UPC-A codes.
+	for len(n) > 12 && n[0] == '0' {
+		n = n[1:]
+	}
+	return n, nil
+}
+
+// barcodeVariants returns the notations a normalized barcode might be stored
+// with in MusicBrainz.
+func barcodeVariants(n string) []string {
+	if len(n) == 12 {
+		return []string{n, "0" + n}
+	}
+	return []string{n}
+}
+
+// normalizeCatNo returns the catalog number in lower case without spaces and
+// punctuation for comparison.
+func normalizeCatNo(catno string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, catno)
+}
+
+// FindReleasesByBarcode searches releases with the given UPC/EAN barcode and
+// returns them ranked by exact barcode match and the preferences of opts
+// (which may be nil).
+func (c *WS2Client) FindReleasesByBarcode(barcode string, opts *FindReleaseOptions) ([]*ReleaseCandidate, error) {
+
+	n, err := NormalizeBarcode(barcode)
+	if err != nil {
+		return nil, err
+	}
+
+	var terms []string
+	for _, v := range barcodeVariants(n) {
+		terms = append(terms, "barcode:"+v)
+	}
+
+	return c.findReleases(strings.Join(terms, " OR "), opts, func(r *Release, cand *ReleaseCandidate) {
+		if rn, err := NormalizeBarcode(r.Barcode); err == nil && rn == n {
+			cand.Rank += findWeightBarcode
+		}
+	})
+}
+
+// FindReleasesByCatalogNumber searches releases with the given catalog number
+// and returns them ranked by exact catalog number match and the preferences
+// of opts (which may be nil).
+func (c *WS2Client) FindReleasesByCatalogNumber(catno string, opts *FindReleaseOptions) ([]*ReleaseCandidate, error) {
+
+	n := normalizeCatNo(catno)
+	if n == "" {
+		return nil, fmt.Errorf("invalid catalog number %q", catno)
+	}
+
+	query := "catno:" + luceneQuote(catno)
+	if compact := strings.Replace(catno, " ", "", -1); compact != catno {
+		query += " OR catno:" + luceneQuote(compact)
+	}
+
+	return c.findReleases(query, opts, func(r *Release, cand *ReleaseCandidate) {
+		for i := range r.LabelInfos {
+			if normalizeCatNo(r.LabelInfos[i].CatalogNumber) == n {
+				cand.Rank += findWeightCatNo
+				cand.LabelInfo = &r.LabelInfos[i]
+				return
+			}
+		}
+	})
+}
+
+// findReleases performs the search query and ranks the results. match adds
+// the rank of the searched identifier.
+func (c *WS2Client) findReleases(query string, opts *FindReleaseOptions, match func(*Release, *ReleaseCandidate)) ([]*ReleaseCandidate, error) {
+
+	if opts == nil {
+		opts = &FindReleaseOptions{}
+	}
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = -1
+	}
+
+	resp, err := c.SearchRelease(query, limit, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []*ReleaseCandidate
+
+	for _, r := range resp.Releases {
+		cand := &ReleaseCandidate{
+			Release:     r,
+			SearchScore: resp.Scores[r],
+		}
+		match(r, cand)
+
+		if opts.Label != "" {
+			for i := range r.LabelInfos {
+				l := r.LabelInfos[i].Label
+				if l != nil && (string(l.ID) == opts.Label || strings.EqualFold(l.Name, opts.Label)) {
+					cand.Rank += findWeightLabel
+					if cand.LabelInfo == nil {
+						cand.LabelInfo = &r.LabelInfos[i]
+					}
+					break
+				}
+			}
+		}
+
+		if opts.Country != "" && strings.EqualFold(r.CountryCode, opts.Country) {
+			cand.Rank += findWeightCountry
+		}
+
+		if opts.Format != "" {
+			for _, m := range r.Mediums {
+				if strings.EqualFold(m.Format, opts.Format) {
+					cand.Rank += findWeightFormat
+					break
+				}
+			}
+		}
+
+		candidates = append(candidates, cand)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Rank != b.Rank {
+			return a.Rank > b.Rank
+		}
+		if a.SearchScore != b.SearchScore {
+			return a.SearchScore > b.SearchScore
+		}
+		return a.Release.ID < b.Release.ID
+	})
+
+	return candidates, nil
+}
@@ -0,0 +1,129 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"net/http"
+	"path"
+	"testing"
+)
+
+func TestNormalizeBarcode(t *testing.T) {
+
+	tests := map[string]string{
+		"724383988327":      "724383988327",
+		"0724383988327":     "724383988327",
+		"00724383988327":    "724383988327",
+		"0 72438 39883 2 7": "724383988327",
+		"4942463511227":     "4942463511227",
+	}
+	for in, want := range tests {
+		n, err := NormalizeBarcode(in)
+		if err != nil {
+			t.Errorf("NormalizeBarcode(%q): %v", in, err)
+		}
+		if n != want {
+			t.Errorf("NormalizeBarcode(%q) = %q, want %q", in, n, want)
+		}
+	}
+
+	if _, err := NormalizeBarcode("724383988328"); err == nil {
+		t.Error("expected error for invalid check digit")
+	}
+}
+
+func serveFindReleases(wantQuery string, t *testing.T) {
+	mux.HandleFunc("/release", func(w http.ResponseWriter, r *http.Request) {
+		if q := r.URL.Query().Get("query"); q != wantQuery {
+			t.Errorf("unexpected query %q, want %q", q, wantQuery)
+		}
+		http.ServeFile(w, r, path.Join("./testdata", "FindReleases.xml"))
+	})
+}
+
+func TestFindReleasesByBarcode(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+	serveFindReleases("barcode:724383988327 OR barcode:0724383988327", t)
+
+	candidates, err := client.FindReleasesByBarcode("0724383988327", &FindReleaseOptions{
+		Format: "Cassette",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var order []MBID
+	for _, c := range candidates {
+		order = append(order, c.Release.ID)
+	}
+
+	want := []MBID{
+		"33333333-3333-4333-8333-333333333333", // barcode and format
+		"22222222-2222-4222-8222-222222222222", // barcode
+		"11111111-1111-4111-8111-111111111111",
+	}
+	if len(order) != len(want) {
+		t.Fatalf("unexpected candidates %v", order)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Errorf("unexpected candidate order %v, want %v", order, want)
+			break
+		}
+	}
+}
+
+func TestFindReleasesByCatalogNumber(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+	serveFindReleases(`catno:"WBRCD 2" OR catno:"WBRCD2"`, t)
+
+	candidates, err := client.FindReleasesByCatalogNumber("WBRCD 2", &FindReleaseOptions{
+		Label:   "Wild Bunch Records",
+		Country: "US",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	best := candidates[0]
+	if best.Release.ID != "22222222-2222-4222-8222-222222222222" {
+		t.Fatalf("unexpected best candidate %s", best.Release.ID)
+	}
+	if best.LabelInfo == nil || best.LabelInfo.CatalogNumber != "WBRCD 2" {
+		t.Errorf("unexpected matched label info %+v", best.LabelInfo)
+	}
+	if want := findWeightCatNo + findWeightLabel + findWeightCountry; best.Rank != want {
+		t.Errorf("unexpected rank %d, want %d", best.Rank, want)
+	}
+
+	if candidates[2].LabelInfo != nil {
+		t.Errorf("expected no label info for release without labels")
+	}
+}
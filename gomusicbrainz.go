@@ -63,23 +63,109 @@ relationships. see
 http://musicbrainz.org/doc/Development/XML_Web_Service/Version_2#inc.3D_arguments_which_affect_subqueries
 Not all of them are supported yet.
 
+MBIDs are validated and normalized with ParseMBID before a lookup is sent. Use
+ParseEntityURL to get the entity type and MBID of a MusicBrainz URL, e.g. one
+pasted by a user.
+
+Lookups of merged entities return the surviving entity with its own MBID and
+are reported to WS2Client.OnRedirect. ResolveCanonicalMBIDs maps stored MBIDs
+to their canonical ones.
+
+
+Generic requests
+
+Lookup and search requests are also provided as generic functions which take
+a context.Context to cancel requests, e.g. Lookup[*Artist](ctx, client, id):
+
+	func Lookup[T MBLookupEntity](ctx context.Context, c *WS2Client, id MBID, inc ...string) (T, error)
+	func Search[T MBSearchEntity](ctx context.Context, c *WS2Client, searchTerm string, limit, offset int) (*SearchResponse[T], error)
+
+Entities without specific Search<ENTITY> response types, like instruments and
+series, return a *SearchResponse.
+
+
+Batch lookups
+
+BatchLookup performs many lookups concurrently and streams the results through
+a channel. Identical lookups are sent only once. Use SetRateLimit to stay within
+the rate limit of musicbrainz.org, e.g. client.SetRateLimit(DefaultRateLimit).
+
+Concurrent identical lookup, search and browse requests of a WS2Client, e.g.
+from several goroutines looking up the same artist, are coalesced into a single
+HTTP request.
+
+
+Mirrors
+
+SetMirrors configures several WS2 servers, e.g. a self-hosted mirror followed
+by musicbrainz.org, each with its own rate limit. Requests fail over to the next
+healthy mirror on connection errors and 5xx responses, see MirrorStatus.
+
+
+Request hooks
+
+WS2Client.Hooks are notified before and after each request with its endpoint,
+status, latency, size, retries and whether the response was shared. NewSlogHook,
+NewLogHook and NewExpvarHook provide ready-made logging and metrics.
+
 
 Browse requets
 
-not supported yet.
+Browse requests return the entities linked to another entity and support
+paging with limit and offset like search requests. Currently the entities of
+collections can be browsed with the BrowseCollection<ENTITY> methods.
+
+Discography browses all release groups and releases of an artist, filters them
+by type and status and returns the release groups in chronological order, each
+with a representative release chosen by a ReleaseSelector. ReleaseSelector
+ranks any releases, e.g. those of a ReleaseGroup, by preferred status, country,
+format, language and script, data quality and date.
+
+
+Release matching
+
+MatchReleases finds the release of a set of audio files, e.g. for tagging. It
+compares candidate releases with the files' album, artist and per-track title
+and length and returns them ranked with a confidence and the track assigned to
+each file. IdentifyRecording returns the best recording for a title, artist and
+duration, e.g. from a radio log.
+
+LookupDiscID looks up the releases of a CD by its disc ID, see TOC.DiscID. With
+a TOC it also returns releases with similar TOCs. Package cuesheet writes CUE
+sheets of releases and reads the TOC of a ripped CD from its CUE sheet.
+
+
+Submissions
+
+Ratings, tags, ISRCs and barcodes can be submitted with the Submit<DATA>
+methods. Submissions require authentication, call SetCredentials with your
+MusicBrainz username and password first. Failed requests return a *WS2Error
+containing the status code and messages of WS2.
+
+
+Strict decoding
+
+Elements and attributes of responses which gomusicbrainz does not decode are
+dropped silently. Set WS2Client.Strict to StrictWarn or StrictError to detect
+such data, e.g. after MusicBrainz added new fields.
 
 */
 package gomusicbrainz
 
 import (
+	"bytes"
+	"context"
 	"encoding/xml"
-	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"path"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 // NewWS2Client returns a new instance of WS2Client. Please provide meaningful
@@ -89,14 +175,12 @@ func NewWS2Client(wsurl, appname, version, contact string) (*WS2Client, error) {
 	c := WS2Client{}
 	var err error
 
-	c.WS2RootURL, err = url.Parse(wsurl)
+	c.WS2RootURL, err = parseRootURL(wsurl)
 	if err != nil {
 		return nil, err
 	}
-	if !strings.HasSuffix(c.WS2RootURL.Path, "ws/2") {
-		c.WS2RootURL.Path = path.Join(c.WS2RootURL.Path, "ws/2")
-	}
 	c.userAgentHeader = appname + "/" + version + " ( " + contact + " ) "
+	c.clientID = appname + "-" + version
 
 	return &c, nil
 }
@@ -105,9 +189,32 @@ func NewWS2Client(wsurl, appname, version, contact string) (*WS2Client, error) {
 type WS2Client struct {
 	WS2RootURL      *url.URL // The API root URL
 	userAgentHeader string
+	clientID        string // value of the client= parameter for submissions
+	username        string
+	password        string
+
+	// Strict selects how elements and attributes of responses which are not
+	// decoded into the result are handled, see StrictMode.
+	Strict     StrictMode
+	warnings   []*UnmappedError
+	warningsMu sync.Mutex
+
+	limiter *rateLimiter
+	mirrors mirrorSet
+	flights flightGroup
+
+	// Hooks are notified before and after every request. They must be set
+	// before c is used.
+	Hooks []RequestHook
+
+	// OnRedirect is called if a lookup returns an entity with another MBID
+	// than requested, i.e. the requested entity was merged. It may be called
+	// concurrently.
+	OnRedirect func(Redirect)
 }
 
-func (c *WS2Client) getRequest(data interface{}, params url.Values, endpoint string) error {
+// newHTTPClient returns a http.Client that preserves headers on redirects.
+func newHTTPClient() *http.Client {
 
 	client := &http.Client{}
 
@@ -130,29 +237,146 @@ func (c *WS2Client) getRequest(data interface{}, params url.Values, endpoint str
 		return nil
 	}
 
-	reqUrl := *c.WS2RootURL
+	return client
+}
+
+// newRequest builds a request for the given WS2 endpoint of the server at base
+// with the User-Agent header set.
+func (c *WS2Client) newRequest(ctx context.Context, base *url.URL, method string, params url.Values, endpoint string, body []byte) (*http.Request, error) {
+
+	reqUrl := *base
 	reqUrl.Path = path.Join(reqUrl.Path, endpoint)
 	reqUrl.RawQuery = params.Encode()
 
-	req, err := http.NewRequest("GET", reqUrl.String(), nil)
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqUrl.String(), bodyReader)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	req.Header.Set("User-Agent", c.userAgentHeader)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/xml; charset=utf-8")
+	}
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return err
+	return req, nil
+}
+
+func (c *WS2Client) getRequest(data interface{}, params url.Values, endpoint string) error {
+	return c.getRequestContext(context.Background(), data, params, endpoint)
+}
+
+// getRequestContext performs a GET request which is canceled with ctx and
+// decodes the response into data.
+func (c *WS2Client) getRequestContext(ctx context.Context, data interface{}, params url.Values, endpoint string) error {
+	return c.request(ctx, "GET", data, nil, params, endpoint, false)
+}
+
+// request performs a request which is canceled with ctx and decodes the
+// response into data unless data is nil. If auth is set, the request is
+// authenticated with the credentials of c. Concurrent identical GET requests
+// share a single response, which every caller decodes into its own data. The
+// request is reported to the Hooks of c.
+func (c *WS2Client) request(ctx context.Context, method string, data interface{}, body []byte, params url.Values, endpoint string, auth bool) error {
+
+	info := &RequestInfo{Method: method, Endpoint: endpoint, Params: params}
+	hookCtxs := c.beforeRequest(ctx, info)
+	start := time.Now()
+
+	fetch := func(ctx context.Context) (*fetched, error) {
+		var resp *http.Response
+		var retries int
+		var err error
+		if auth {
+			resp, retries, err = c.sendAuth(ctx, method, params, endpoint, body)
+		} else {
+			resp, _, retries, err = c.send(ctx, method, params, endpoint, body)
+		}
+		res := &fetched{retries: retries}
+		if err != nil {
+			return res, err
+		}
+		defer resp.Body.Close()
+		res.status = resp.StatusCode
+
+		if err = checkResponse(resp); err != nil {
+			return res, err
+		}
+
+		res.body, err = ioutil.ReadAll(resp.Body)
+		return res, err
 	}
-	defer resp.Body.Close()
 
-	decoder := xml.NewDecoder(resp.Body)
+	var res *fetched
+	var shared bool
+	var err error
+	if method == "GET" {
+		// authenticated responses may contain private data
+		key := requestKey(endpoint, params)
+		if auth {
+			key = "auth " + key
+		}
+		res, shared, err = c.flights.do(ctx, key, fetch)
+	} else {
+		res, err = fetch(ctx)
+	}
+	if err == nil && data != nil {
+		err = c.decode(bytes.NewReader(res.body), data, endpoint)
+	}
 
-	if err = decoder.Decode(data); err != nil {
-		return err
+	info.Latency = time.Since(start)
+	info.CacheHit = shared
+	info.Err = err
+	if res != nil {
+		info.Status = res.status
+		info.Bytes = len(res.body)
+		if !shared {
+			info.Retries = res.retries
+		}
+	}
+	c.afterRequest(hookCtxs, info)
+
+	return err
+}
+
+// WS2Error is returned for requests WS2 answered with an error status. It
+// contains the status code and the messages of the error document e.g.
+// "Your requests are exceeding the allowable rate limit".
+type WS2Error struct {
+	StatusCode int
+	Messages   []string
+}
+
+func (e *WS2Error) Error() string {
+	if len(e.Messages) == 0 {
+		return fmt.Sprintf("ws2: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("ws2: %d %s", e.StatusCode, e.Messages[0])
+}
+
+// checkResponse returns a *WS2Error if resp has a non-2xx status code.
+func checkResponse(resp *http.Response) error {
+
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return nil
+	}
+
+	var errDoc struct {
+		XMLName xml.Name `xml:"error"`
+		Texts   []string `xml:"text"`
+	}
+	// the body is not necessarily an error document (e.g. proxy errors), so
+	// decoding errors are ignored.
+	xml.NewDecoder(resp.Body).Decode(&errDoc)
+
+	return &WS2Error{
+		StatusCode: resp.StatusCode,
+		Messages:   errDoc.Texts,
 	}
-	return nil
 }
 
 // intParamToString returns an empty string for -1.
@@ -163,7 +387,7 @@ func intParamToString(i int) string {
 	return strconv.Itoa(i)
 }
 
-func (c *WS2Client) searchRequest(endpoint string, result interface{}, searchTerm string, limit, offset int) error {
+func (c *WS2Client) searchRequest(ctx context.Context, endpoint string, result interface{}, searchTerm string, limit, offset int) error {
 
 	params := url.Values{
 		"query":  {searchTerm},
@@ -171,11 +395,40 @@ func (c *WS2Client) searchRequest(endpoint string, result interface{}, searchTer
 		"offset": {intParamToString(offset)},
 	}
 
-	if err := c.getRequest(result, params, endpoint); err != nil {
-		return err
+	return c.getRequestContext(ctx, result, params, endpoint)
+}
+
+// browseRequest performs a browse request for entities of endpoint which are
+// linked to the entity with the given MBID e.g. releases of a collection.
+// Requests are authenticated if credentials are set to allow browsing of
+// private data.
+func (c *WS2Client) browseRequest(endpoint string, result interface{}, linked string, id MBID, limit, offset int, inc []string) error {
+	return c.browseRequestContext(context.Background(), endpoint, result, url.Values{linked: {string(id)}}, limit, offset, inc)
+}
+
+// browseRequestContext is like browseRequest but with a context. filter holds
+// the linked entity and filters like type or status and is not modified.
+func (c *WS2Client) browseRequestContext(ctx context.Context, endpoint string, result interface{}, filter url.Values, limit, offset int, inc []string) error {
+
+	params := url.Values{}
+	for k, v := range filter {
+		params[k] = v
+	}
+	params.Set("limit", intParamToString(limit))
+	params.Set("offset", intParamToString(offset))
+	if inc != nil {
+		params.Set("inc", strings.Join(inc, "+"))
 	}
 
-	return nil
+	if c.username != "" {
+		return c.authRequestContext(ctx, "GET", result, nil, params, endpoint)
+	}
+	return c.getRequestContext(ctx, result, params, endpoint)
+}
+
+// luceneQuote returns s as quoted lucene phrase.
+func luceneQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
 }
 
 func encodeInc(inc []string) url.Values {
@@ -190,14 +443,5 @@ func encodeInc(inc []string) url.Values {
 // Lookup performs a WS2 lookup request for the given entity (e.g. Artist,
 // Label, ...)
 func (c *WS2Client) Lookup(entity MBLookupEntity, inc ...string) error {
-	if entity.Id() == "" {
-		return errors.New("can't perform lookup without ID.")
-	}
-
-	return c.getRequest(entity.lookupResult(), encodeInc(inc),
-		path.Join(
-			entity.apiEndpoint(),
-			string(entity.Id()),
-		),
-	)
+	return c.lookup(context.Background(), entity, entity.Id(), inc)
 }
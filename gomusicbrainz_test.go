@@ -25,8 +25,6 @@
 
 package gomusicbrainz
 
-// TODO use testdata from https://github.com/metabrainz/mmd-schema/tree/master/test-data/valid
-
 import (
 	"fmt"
 	"net/http"
@@ -0,0 +1,201 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"context"
+	"expvar"
+	"log"
+	"log/slog"
+	"net/url"
+	"time"
+)
+
+// RequestInfo describes a request of a WS2Client. Endpoint and Params are set
+// before the request, the other fields once it is done.
+type RequestInfo struct {
+	Method   string
+	Endpoint string
+	Params   url.Values
+
+	Status   int // HTTP status code, 0 if no response was received
+	Latency  time.Duration
+	Bytes    int  // size of the response body
+	Retries  int  // retries of throttled requests and failovers, see SetMirrors
+	CacheHit bool // the response was shared with a concurrent identical request
+	Err      error
+}
+
+// RequestHook observes the requests of a WS2Client, e.g. for metrics, tracing
+// or logging. BeforeRequest may return a derived context, e.g. holding a
+// tracing span, which is passed to AfterRequest. Hooks are called from the
+// goroutine making the request and must be safe for concurrent use.
+type RequestHook interface {
+	BeforeRequest(ctx context.Context, info *RequestInfo) context.Context
+	AfterRequest(ctx context.Context, info *RequestInfo)
+}
+
+// beforeRequest calls BeforeRequest of all hooks of c and returns the
+// contexts for AfterRequest.
+func (c *WS2Client) beforeRequest(ctx context.Context, info *RequestInfo) []context.Context {
+
+	if len(c.Hooks) == 0 {
+		return nil
+	}
+
+	ctxs := make([]context.Context, len(c.Hooks))
+	for i, h := range c.Hooks {
+		ctxs[i] = h.BeforeRequest(ctx, info)
+		if ctxs[i] == nil {
+			ctxs[i] = ctx
+		}
+	}
+	return ctxs
+}
+
+// afterRequest calls AfterRequest of all hooks of c with the contexts
+// returned by beforeRequest.
+func (c *WS2Client) afterRequest(ctxs []context.Context, info *RequestInfo) {
+	for i, h := range c.Hooks {
+		h.AfterRequest(ctxs[i], info)
+	}
+}
+
+// slogHook logs requests to a slog.Logger.
+type slogHook struct {
+	logger *slog.Logger
+}
+
+// NewSlogHook returns a RequestHook which logs every request to logger at
+// debug level, failed requests at warn level.
+func NewSlogHook(logger *slog.Logger) RequestHook {
+	return slogHook{logger}
+}
+
+func (h slogHook) BeforeRequest(ctx context.Context, info *RequestInfo) context.Context {
+	return ctx
+}
+
+func (h slogHook) AfterRequest(ctx context.Context, info *RequestInfo) {
+
+	attrs := []slog.Attr{
+		slog.String("method", info.Method),
+		slog.String("endpoint", info.Endpoint),
+		slog.String("params", info.Params.Encode()),
+		slog.Int("status", info.Status),
+		slog.Duration("latency", info.Latency),
+		slog.Int("bytes", info.Bytes),
+		slog.Int("retries", info.Retries),
+		slog.Bool("cache_hit", info.CacheHit),
+	}
+
+	if info.Err != nil {
+		attrs = append(attrs, slog.String("error", info.Err.Error()))
+		h.logger.LogAttrs(ctx, slog.LevelWarn, "ws2 request failed", attrs...)
+		return
+	}
+	h.logger.LogAttrs(ctx, slog.LevelDebug, "ws2 request", attrs...)
+}
+
+// logHook logs requests to a log.Logger.
+type logHook struct {
+	logger *log.Logger
+}
+
+// NewLogHook returns a RequestHook which logs every request to logger. If
+// logger is nil the standard logger is used.
+func NewLogHook(logger *log.Logger) RequestHook {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return logHook{logger}
+}
+
+func (h logHook) BeforeRequest(ctx context.Context, info *RequestInfo) context.Context {
+	return ctx
+}
+
+func (h logHook) AfterRequest(ctx context.Context, info *RequestInfo) {
+
+	msg := "ws2 "
+	if info.Method != "GET" {
+		msg += info.Method + " "
+	}
+	msg += info.Endpoint
+	if len(info.Params) > 0 {
+		msg += "?" + info.Params.Encode()
+	}
+
+	if info.Err != nil {
+		h.logger.Printf("%s failed after %v (status %d, %d retries): %v",
+			msg, info.Latency, info.Status, info.Retries, info.Err)
+		return
+	}
+	h.logger.Printf("%s %d %v %d bytes, %d retries, cache hit %t",
+		msg, info.Status, info.Latency, info.Bytes, info.Retries, info.CacheHit)
+}
+
+// ExpvarHook is a RequestHook which counts requests in an expvar.Map with the
+// keys requests, errors, retries, cache_hits, bytes and latency_ns (the total
+// latency of all requests).
+type ExpvarHook struct {
+	vars *expvar.Map
+}
+
+// NewExpvarHook returns an ExpvarHook. Its counters are published as expvar
+// variable name unless name is empty. Like expvar.Publish it panics if name is
+// already in use.
+func NewExpvarHook(name string) *ExpvarHook {
+
+	h := &ExpvarHook{vars: new(expvar.Map).Init()}
+	if name != "" {
+		expvar.Publish(name, h.vars)
+	}
+	return h
+}
+
+// Vars returns the counters of h.
+func (h *ExpvarHook) Vars() *expvar.Map {
+	return h.vars
+}
+
+func (h *ExpvarHook) BeforeRequest(ctx context.Context, info *RequestInfo) context.Context {
+	return ctx
+}
+
+func (h *ExpvarHook) AfterRequest(ctx context.Context, info *RequestInfo) {
+
+	h.vars.Add("requests", 1)
+	if info.Err != nil {
+		h.vars.Add("errors", 1)
+	}
+	h.vars.Add("retries", int64(info.Retries))
+	if info.CacheHit {
+		h.vars.Add("cache_hits", 1)
+	}
+	h.vars.Add("bytes", int64(info.Bytes))
+	h.vars.Add("latency_ns", int64(info.Latency))
+}
@@ -0,0 +1,166 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+type ctxKey struct{}
+
+// recordingHook records the RequestInfo passed to AfterRequest.
+type recordingHook struct {
+	before int
+	infos  []RequestInfo
+	ctxOK  bool
+}
+
+func (h *recordingHook) BeforeRequest(ctx context.Context, info *RequestInfo) context.Context {
+	h.before++
+	return context.WithValue(ctx, ctxKey{}, "span")
+}
+
+func (h *recordingHook) AfterRequest(ctx context.Context, info *RequestInfo) {
+	h.ctxOK = ctx.Value(ctxKey{}) == "span"
+	h.infos = append(h.infos, *info)
+}
+
+func TestRequestHooks(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+	serveTestFile("/artist/10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8", "LookupArtist.xml", t)
+	mux.HandleFunc("/label/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+	})
+
+	rec := &recordingHook{}
+	counters := NewExpvarHook("")
+	client.Hooks = []RequestHook{rec, counters}
+
+	if _, err := client.LookupArtist("10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8", "aliases"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.LookupLabel("46f0f4cd-8aab-4b33-b698-f459faf64190"); err == nil {
+		t.Fatal("expected error for 404")
+	}
+
+	if rec.before != 2 || len(rec.infos) != 2 {
+		t.Fatalf("got %d/%d hook calls, want 2/2", rec.before, len(rec.infos))
+	}
+	if !rec.ctxOK {
+		t.Error("AfterRequest did not get the context of BeforeRequest")
+	}
+
+	ok := rec.infos[0]
+	if ok.Endpoint != "/artist/10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8" ||
+		ok.Params.Get("inc") != "aliases" ||
+		ok.Status != http.StatusOK ||
+		ok.Bytes == 0 ||
+		ok.Latency <= 0 ||
+		ok.Err != nil {
+		t.Errorf("unexpected info %+v", ok)
+	}
+
+	failed := rec.infos[1]
+	if failed.Status != http.StatusNotFound || failed.Err == nil {
+		t.Errorf("unexpected info %+v", failed)
+	}
+
+	vars := counters.Vars()
+	for key, want := range map[string]string{"requests": "2", "errors": "1", "retries": "0"} {
+		if got := vars.Get(key).String(); got != want {
+			t.Errorf("counter %s is %s, want %s", key, got, want)
+		}
+	}
+	if vars.Get("bytes").String() == "0" {
+		t.Error("bytes were not counted")
+	}
+}
+
+func TestRequestHookRetries(t *testing.T) {
+
+	downStatus, upStatus := int32(http.StatusInternalServerError), int32(http.StatusOK)
+	var hits int32
+	down := newMirrorServer(&downStatus, &hits)
+	defer down.Close()
+	up := newMirrorServer(&upStatus, &hits)
+	defer up.Close()
+
+	c, _ := NewWS2Client("http://unused.invalid", "Application Name", "Version", "Contact")
+	c.SetMirrors(Mirror{URL: down.URL}, Mirror{URL: up.URL})
+	rec := &recordingHook{}
+	c.Hooks = []RequestHook{rec}
+
+	if _, err := c.LookupArtist("10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8"); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&hits); n != 2 {
+		t.Errorf("got %d requests, want 2", n)
+	}
+	if len(rec.infos) != 1 || rec.infos[0].Retries != 1 {
+		t.Errorf("unexpected infos %+v", rec.infos)
+	}
+}
+
+func TestLogHooks(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+	serveTestFile("/artist/10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8", "LookupArtist.xml", t)
+
+	var slogBuf, logBuf bytes.Buffer
+	client.Hooks = []RequestHook{
+		NewSlogHook(slog.New(slog.NewTextHandler(&slogBuf, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+		NewLogHook(log.New(&logBuf, "", 0)),
+	}
+
+	if _, err := client.LookupArtist("10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"msg=\"ws2 request\"",
+		"endpoint=/artist/10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8",
+		"status=200",
+		"cache_hit=false",
+	} {
+		if !strings.Contains(slogBuf.String(), want) {
+			t.Errorf("slog output %q does not contain %q", slogBuf.String(), want)
+		}
+	}
+
+	if want := "ws2 /artist/10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8 200 "; !strings.HasPrefix(logBuf.String(), want) {
+		t.Errorf("log output %q does not start with %q", logBuf.String(), want)
+	}
+}
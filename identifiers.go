@@ -0,0 +1,177 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ParseMBID returns s as MBID in its canonical lowercase form. An error is
+// returned if s is not a UUID in the 8-4-4-4-12 hex digit format used by
+// MusicBrainz. Surrounding white space is ignored.
+func ParseMBID(s string) (MBID, error) {
+
+	n := strings.ToLower(strings.TrimSpace(s))
+
+	if len(n) != 36 {
+		return "", fmt.Errorf("invalid MBID %q: must have 36 characters", s)
+	}
+
+	for i, r := range n {
+		switch i {
+		case 8, 13, 18, 23:
+			if r != '-' {
+				return "", fmt.Errorf("invalid MBID %q: expected '-' at position %d", s, i)
+			}
+		default:
+			if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'f') {
+				return "", fmt.Errorf("invalid MBID %q: non-hex character %q", s, r)
+			}
+		}
+	}
+
+	return MBID(n), nil
+}
+
+// entityURLTypes are the entity types in MusicBrainz URLs that ParseEntityURL
+// accepts.
+var entityURLTypes = map[string]bool{
+	"area":          true,
+	"artist":        true,
+	"collection":    true,
+	"event":         true,
+	"instrument":    true,
+	"label":         true,
+	"place":         true,
+	"recording":     true,
+	"release":       true,
+	"release-group": true,
+	"series":        true,
+	"work":          true,
+}
+
+// ParseEntityURL returns the entity type (e.g. "release-group") and MBID of a
+// MusicBrainz entity URL like
+// https://musicbrainz.org/release/07832b54-8266-47d5-bb0e-62c7f2cf5da5 or
+// https://beta.musicbrainz.org/artist/10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8/releases.
+// Hosts of musicbrainz.org and its beta and test servers as well as WS2 lookup
+// URLs (/ws/2/<entity>/<mbid>) are accepted.
+func ParseEntityURL(rawurl string) (entity string, id MBID, err error) {
+
+	u, err := url.Parse(strings.TrimSpace(rawurl))
+	if err != nil {
+		return "", "", err
+	}
+
+	switch strings.ToLower(u.Hostname()) {
+	case "musicbrainz.org", "www.musicbrainz.org", "beta.musicbrainz.org", "test.musicbrainz.org":
+	default:
+		return "", "", fmt.Errorf("not a MusicBrainz URL %q", rawurl)
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) >= 2 && parts[0] == "ws" && parts[1] == "2" {
+		parts = parts[2:]
+	}
+	if len(parts) < 2 || !entityURLTypes[parts[0]] {
+		return "", "", fmt.Errorf("not a MusicBrainz entity URL %q", rawurl)
+	}
+
+	id, err = ParseMBID(parts[1])
+	if err != nil {
+		return "", "", err
+	}
+	return parts[0], id, nil
+}
+
+// NormalizeISRC returns isrc in its canonical 12 character form e.g.
+// "GB-AAA-96-00001" becomes "GBAAA9600001". An error is returned if isrc is
+// not a well-formed International Standard Recording Code. Note that ISRCs
+// have no check digit, so only the format can be verified.
+func NormalizeISRC(isrc string) (string, error) {
+
+	n := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(isrc))
+
+	if len(n) != 12 {
+		return "", fmt.Errorf("invalid ISRC %q: must have 12 characters", isrc)
+	}
+
+	for i, r := range n {
+		switch {
+		case i < 2 && (r < 'A' || r > 'Z'):
+			return "", fmt.Errorf("invalid ISRC %q: country code must be alphabetic", isrc)
+		case i >= 2 && i < 5 && !(r >= 'A' && r <= 'Z' || r >= '0' && r <= '9'):
+			return "", fmt.Errorf("invalid ISRC %q: registrant code must be alphanumeric", isrc)
+		case i >= 5 && (r < '0' || r > '9'):
+			return "", fmt.Errorf("invalid ISRC %q: year and designation code must be numeric", isrc)
+		}
+	}
+
+	return n, nil
+}
+
+// ValidISRC reports whether isrc is a well-formed ISRC.
+func ValidISRC(isrc string) bool {
+	_, err := NormalizeISRC(isrc)
+	return err == nil
+}
+
+// ValidBarcode reports whether barcode is a GTIN (EAN-8, UPC-A, EAN-13 or
+// GTIN-14) with a correct check digit.
+func ValidBarcode(barcode string) bool {
+
+	switch len(barcode) {
+	case 8, 12, 13, 14:
+	default:
+		return false
+	}
+
+	for _, r := range barcode {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return gtinCheckDigit(barcode[:len(barcode)-1]) == barcode[len(barcode)-1]
+}
+
+// gtinCheckDigit calculates the GS1 check digit for the given digits. Weights
+// of 3 and 1 alternate starting with 3 at the rightmost digit.
+func gtinCheckDigit(digits string) byte {
+
+	sum := 0
+	for i := 0; i < len(digits); i++ {
+		d := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+
+	return byte('0' + (10-sum%10)%10)
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import "testing"
+
+func TestNormalizeISRC(t *testing.T) {
+
+	valid := map[string]string{
+		"GBAAA9600001":    "GBAAA9600001",
+		"gb-aaa-96-00001": "GBAAA9600001",
+		"US S1Z 99 00001": "USS1Z9900001",
+	}
+	for in, want := range valid {
+		n, err := NormalizeISRC(in)
+		if err != nil {
+			t.Errorf("NormalizeISRC(%q): %v", in, err)
+		}
+		if n != want {
+			t.Errorf("NormalizeISRC(%q) = %q, want %q", in, n, want)
+		}
+	}
+
+	for _, in := range []string{"", "GBAAA960000", "1BAAA9600001", "GBA-A9600001", "GBAAA96000O1"} {
+		if ValidISRC(in) {
+			t.Errorf("ValidISRC(%q) = true, want false", in)
+		}
+	}
+}
+
+func TestValidBarcode(t *testing.T) {
+
+	for _, b := range []string{"075992659222", "724383988327", "4942463511227", "96385074"} {
+		if !ValidBarcode(b) {
+			t.Errorf("ValidBarcode(%q) = false, want true", b)
+		}
+	}
+
+	for _, b := range []string{"", "075992659223", "4942463511228", "7243839883a7", "12345"} {
+		if ValidBarcode(b) {
+			t.Errorf("ValidBarcode(%q) = true, want false", b)
+		}
+	}
+}
+
+func TestParseMBID(t *testing.T) {
+
+	valid := map[string]MBID{
+		"10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8":    "10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8",
+		"10ADBE5E-A2C0-4BF3-8249-2B4CBF6E6CA8":    "10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8",
+		" 10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8\n": "10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8",
+	}
+	for in, want := range valid {
+		id, err := ParseMBID(in)
+		if err != nil {
+			t.Errorf("ParseMBID(%q): %v", in, err)
+		}
+		if id != want {
+			t.Errorf("ParseMBID(%q) = %q, want %q", in, id, want)
+		}
+	}
+
+	for _, in := range []string{
+		"",
+		"10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca",
+		"10adbe5ea2c04bf382492b4cbf6e6ca8",
+		"10adbe5e-a2c0-4bf3-8249_2b4cbf6e6ca8",
+		"10adbe5g-a2c0-4bf3-8249-2b4cbf6e6ca8",
+	} {
+		if _, err := ParseMBID(in); err == nil {
+			t.Errorf("ParseMBID(%q) succeeded, want error", in)
+		}
+	}
+}
+
+func TestParseEntityURL(t *testing.T) {
+
+	valid := map[string][2]string{
+		"https://musicbrainz.org/release/07832b54-8266-47d5-bb0e-62c7f2cf5da5":                  {"release", "07832b54-8266-47d5-bb0e-62c7f2cf5da5"},
+		"http://www.musicbrainz.org/release-group/1dc4c347-a1db-32aa-b14f-bc9cc507b843":         {"release-group", "1dc4c347-a1db-32aa-b14f-bc9cc507b843"},
+		"https://beta.musicbrainz.org/artist/10ADBE5E-A2C0-4BF3-8249-2B4CBF6E6CA8/releases":     {"artist", "10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8"},
+		"https://test.musicbrainz.org/label/46f0f4cd-8aab-4b33-b698-f459faf64190#top":           {"label", "46f0f4cd-8aab-4b33-b698-f459faf64190"},
+		"https://musicbrainz.org/ws/2/recording/b1a9c0e9-d987-4042-ae91-78d6a3267d69?inc=isrcs": {"recording", "b1a9c0e9-d987-4042-ae91-78d6a3267d69"},
+	}
+	for in, want := range valid {
+		entity, id, err := ParseEntityURL(in)
+		if err != nil {
+			t.Errorf("ParseEntityURL(%q): %v", in, err)
+			continue
+		}
+		if entity != want[0] || id != MBID(want[1]) {
+			t.Errorf("ParseEntityURL(%q) = %q, %q, want %q, %q", in, entity, id, want[0], want[1])
+		}
+	}
+
+	for _, in := range []string{
+		"",
+		"https://example.com/release/07832b54-8266-47d5-bb0e-62c7f2cf5da5",
+		"https://musicbrainz.org/release/",
+		"https://musicbrainz.org/search?query=foo",
+		"https://musicbrainz.org/user/07832b54-8266-47d5-bb0e-62c7f2cf5da5",
+		"https://musicbrainz.org/release/07832b54",
+	} {
+		if _, _, err := ParseEntityURL(in); err == nil {
+			t.Errorf("ParseEntityURL(%q) succeeded, want error", in)
+		}
+	}
+}
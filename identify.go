@@ -0,0 +1,246 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Weights of the properties compared by IdentifyRecording.
+const (
+	identifyWeightSearch        = 40
+	identifyWeightDuration      = 30
+	identifyWeightTitle         = 15
+	identifyWeightArtist        = 15
+	identifyWeightOfficialAlbum = 10
+)
+
+// DefaultIdentifyTolerance is the maximum duration difference of recordings
+// found by IdentifyRecording if IdentifyOptions.Tolerance is not set.
+const DefaultIdentifyTolerance = 10 * time.Second
+
+// featRegexp matches featured artists in titles and artist names, either as
+// parenthesized or bracketed clause like " (feat. Someone)" or as suffix after
+// a space like " ft. Someone". Titles starting with "Feat" are left alone.
+var featRegexp = regexp.MustCompile(`(?i)\s*[(\[]\s*(feat\.?|ft\.|featuring)\s[^)\]]*[)\]]|\s+(feat\.?|ft\.|featuring)\s.*$`)
+
+// IdentifyOptions configures IdentifyRecording.
+type IdentifyOptions struct {
+	// Tolerance is the maximum difference of a recording's length to the
+	// searched duration (default DefaultIdentifyTolerance).
+	Tolerance time.Duration
+
+	// PreferOfficialAlbums ranks recordings which appear on official
+	// albums higher than those only found on singles, compilations,
+	// bootlegs and the like.
+	PreferOfficialAlbums bool
+
+	// Limit is the number of search results to rank (1-100, default 25).
+	Limit int
+}
+
+// RecordingMatch is a recording found by IdentifyRecording.
+type RecordingMatch struct {
+	Recording   *Recording
+	Confidence  float64 // from 0 to 1
+	SearchScore int
+}
+
+// IdentifyRecording returns the recording which best matches title, artist
+// and duration, e.g. of an entry of a radio log. Punctuation, diacritics and
+// featured artists like " (feat. Someone)" are ignored. The search is
+// restricted to recordings within the duration tolerance of opts, unless no
+// such recording is found or duration is 0. Results are ranked by their search
+// score, length, title and artist similarity. opts may be nil.
+func (c *WS2Client) IdentifyRecording(ctx context.Context, title, artist string, duration time.Duration, opts *IdentifyOptions) (*RecordingMatch, error) {
+
+	if opts == nil {
+		opts = &IdentifyOptions{}
+	}
+	tolerance := opts.Tolerance
+	if tolerance <= 0 {
+		tolerance = DefaultIdentifyTolerance
+	}
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = -1
+	}
+
+	title, artist = stripFeat(title), stripFeat(artist)
+	if title == "" {
+		return nil, errors.New("can't identify recording without title.")
+	}
+
+	query := "recording:" + luceneQuote(foldDiacritics(title))
+	if artist != "" {
+		query += " AND artist:" + luceneQuote(foldDiacritics(artist))
+	}
+
+	var results []Scored[*Recording]
+	if duration > 0 {
+		resp, err := Search[*Recording](ctx, c, fmt.Sprintf("%s AND dur:[%d TO %d]", query,
+			max(duration-tolerance, 0).Milliseconds(), (duration+tolerance).Milliseconds()), limit, -1)
+		if err != nil {
+			return nil, err
+		}
+		results = resp.Results
+	}
+	if len(results) == 0 {
+		resp, err := Search[*Recording](ctx, c, query, limit, -1)
+		if err != nil {
+			return nil, err
+		}
+		results = resp.Results
+	}
+
+	if len(results) == 0 {
+		return nil, errors.New("no matching recording found.")
+	}
+
+	matches := make([]*RecordingMatch, len(results))
+	for i, r := range results {
+		matches[i] = &RecordingMatch{
+			Recording:   r.Entity,
+			SearchScore: r.Score,
+			Confidence:  identifyConfidence(r, title, artist, duration, tolerance, opts.PreferOfficialAlbums),
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Confidence > matches[j].Confidence
+	})
+
+	return matches[0], nil
+}
+
+// identifyConfidence rates how well the search result r matches.
+func identifyConfidence(r Scored[*Recording], title, artist string, duration, tolerance time.Duration, preferOfficialAlbums bool) float64 {
+
+	var score, weights float64
+	addScore := func(weight int, s float64) {
+		score += float64(weight) * s
+		weights += float64(weight)
+	}
+
+	addScore(identifyWeightSearch, float64(r.Score)/100)
+	addScore(identifyWeightTitle, similarity(title, stripFeat(r.Entity.Title)))
+
+	if artist != "" {
+		addScore(identifyWeightArtist, artistCreditSimilarity(artist, r.Entity.ArtistCredit))
+	}
+
+	if duration > 0 && r.Entity.Length > 0 {
+		diff := duration - time.Duration(r.Entity.Length)*time.Millisecond
+		if diff < 0 {
+			diff = -diff
+		}
+		s := 0.0
+		if diff < tolerance {
+			s = 1 - float64(diff)/float64(tolerance)
+		}
+		addScore(identifyWeightDuration, s)
+	}
+
+	if preferOfficialAlbums {
+		s := 0.0
+		for _, rel := range r.Entity.Releases {
+			if isOfficialAlbum(rel) {
+				s = 1
+				break
+			}
+		}
+		addScore(identifyWeightOfficialAlbum, s)
+	}
+
+	return score / weights
+}
+
+// isOfficialAlbum reports whether r is an official release of a plain album,
+// i.e. no compilation, live album or the like.
+func isOfficialAlbum(r *Release) bool {
+
+	if r.Status != ReleaseStatusOfficial || len(r.ReleaseGroup.SecondaryTypes) > 0 {
+		return false
+	}
+	if r.ReleaseGroup.PrimaryType != "" {
+		return r.ReleaseGroup.PrimaryType == ReleaseGroupTypeAlbum
+	}
+	return r.ReleaseGroup.Type == "Album"
+}
+
+// stripFeat removes featured artists from s.
+func stripFeat(s string) string {
+	return strings.TrimSpace(featRegexp.ReplaceAllString(s, ""))
+}
+
+// diacritics maps Latin letters with diacritics to their base letters.
+var diacritics = func() map[rune]string {
+	m := make(map[rune]string)
+	for base, letters := range map[string]string{
+		"A": "ÀÁÂÃÄÅĀĂĄ", "a": "àáâãäåāăą",
+		"C": "ÇĆĈĊČ", "c": "çćĉċč",
+		"D": "ĎĐ", "d": "ďđ",
+		"E": "ÈÉÊËĒĔĖĘĚ", "e": "èéêëēĕėęě",
+		"G": "ĜĞĠĢ", "g": "ĝğġģ",
+		"I": "ÌÍÎÏĨĪĬĮİ", "i": "ìíîïĩīĭįı",
+		"L": "ĹĻĽĿŁ", "l": "ĺļľŀł",
+		"N": "ÑŃŅŇ", "n": "ñńņň",
+		"O": "ÒÓÔÕÖØŌŎŐ", "o": "òóôõöøōŏő",
+		"R": "ŔŖŘ", "r": "ŕŗř",
+		"S": "ŚŜŞŠ", "s": "śŝşš",
+		"T": "ŢŤ", "t": "ţť",
+		"U": "ÙÚÛÜŨŪŬŮŰŲ", "u": "ùúûüũūŭůűų",
+		"Y": "ÝŸ", "y": "ýÿ",
+		"Z": "ŹŻŽ", "z": "źżž",
+		"AE": "Æ", "ae": "æ", "OE": "Œ", "oe": "œ", "ss": "ß",
+	} {
+		for _, r := range letters {
+			m[r] = base
+		}
+	}
+	return m
+}()
+
+// foldDiacritics replaces Latin letters with diacritics in s by their base
+// letters, e.g. "Sigur Rós" becomes "Sigur Ros".
+func foldDiacritics(s string) string {
+
+	var b strings.Builder
+	for _, r := range s {
+		if base, ok := diacritics[r]; ok {
+			b.WriteString(base)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,140 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIdentifyRecording(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+
+	var queries []string
+	mux.HandleFunc("/recording", func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("query"))
+		http.ServeFile(w, r, path.Join("./testdata", "IdentifyRecording.xml"))
+	})
+
+	m, err := client.IdentifyRecording(context.Background(),
+		"Paper Boats (feat. Kit North)", "Amélie Sørensen feat. Kit North", 214*time.Second, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `recording:"Paper Boats" AND artist:"Amelie Sorensen" AND dur:[204000 TO 224000]`
+	if len(queries) != 1 || queries[0] != want {
+		t.Errorf("got queries %q, want %q", queries, want)
+	}
+
+	if m.Recording.ID != "2e7c1f3a-6b4d-4c8e-9a1f-3d5b7c9e1a2b" || m.SearchScore != 100 {
+		t.Errorf("got recording %s with score %d", m.Recording.ID, m.SearchScore)
+	}
+	if m.Confidence < 0.99 {
+		t.Errorf("got confidence %f, want ~1", m.Confidence)
+	}
+
+	// the single is outranked by the album version
+	m, err = client.IdentifyRecording(context.Background(),
+		"Paper Boats", "Amélie Sørensen", 214*time.Second, &IdentifyOptions{PreferOfficialAlbums: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Recording.ID != "3f8d2a4b-7c5e-4d9f-ab20-4e6c8d0f2b3c" {
+		t.Errorf("got recording %s, want the album version", m.Recording.ID)
+	}
+
+	// the duration range does not start below 0
+	queries = nil
+	client.IdentifyRecording(context.Background(), "Paper Boats", "", 4*time.Second, nil)
+	if want := `recording:"Paper Boats" AND dur:[0 TO 14000]`; len(queries) == 0 || queries[0] != want {
+		t.Errorf("got queries %q, want %q", queries, want)
+	}
+}
+
+func TestIdentifyRecordingFallback(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+
+	var queries []string
+	mux.HandleFunc("/recording", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("query")
+		queries = append(queries, q)
+		if strings.Contains(q, "dur:") {
+			w.Write([]byte(`<metadata xmlns="http://musicbrainz.org/ns/mmd-2.0#"><recording-list count="0" offset="0"/></metadata>`))
+			return
+		}
+		http.ServeFile(w, r, path.Join("./testdata", "IdentifyRecording.xml"))
+	})
+
+	// no recording within the tolerance, so the live version is closest
+	m, err := client.IdentifyRecording(context.Background(),
+		"Paper Boats", "Amélie Sørensen", 224*time.Second, &IdentifyOptions{Tolerance: 2 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(queries) != 2 || strings.Contains(queries[1], "dur:") {
+		t.Errorf("unexpected queries %q", queries)
+	}
+	if m.Recording.ID != "4a9e3b5c-8d6f-4ea0-bc31-5f7d9e1a3c4d" {
+		t.Errorf("got recording %s, want the live version", m.Recording.ID)
+	}
+
+	if _, err := client.IdentifyRecording(context.Background(), " feat. Someone", "", 0, nil); err == nil {
+		t.Error("expected error for empty title")
+	}
+}
+
+func TestStripFeat(t *testing.T) {
+
+	for in, want := range map[string]string{
+		"Paper Boats (feat. Kit North)":        "Paper Boats",
+		"Paper Boats [Feat. Kit North]":        "Paper Boats",
+		"Paper Boats ft. Kit North":            "Paper Boats",
+		"Paper Boats feat Kit North":           "Paper Boats",
+		"Amélie featuring Kit North":           "Amélie",
+		"Defeat the Feather":                   "Defeat the Feather",
+		"Feat":                                 "Feat",
+		"Feat of Strength":                     "Feat of Strength",
+		"Ft. Lauderdale":                       "Ft. Lauderdale",
+		"Paper Boats (feat. Kit North) (Live)": "Paper Boats (Live)",
+	} {
+		if got := stripFeat(in); got != want {
+			t.Errorf("stripFeat(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	if got := foldDiacritics("Sigur Rós, Æther, Łódź, straße"); got != "Sigur Ros, AEther, Lodz, strasse" {
+		t.Errorf("foldDiacritics returned %q", got)
+	}
+}
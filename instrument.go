@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"context"
+	"encoding/xml"
+)
+
+// Instrument represents a musical instrument e.g. a guitar or a theremin. See
+// https://musicbrainz.org/doc/Instrument
+type Instrument struct {
+	ID             MBID               `xml:"id,attr"`
+	Type           string             `xml:"type,attr,omitempty"`
+	TypeID         MBID               `xml:"type-id,attr,omitempty"`
+	Name           string             `xml:"name,omitempty"`
+	Disambiguation string             `xml:"disambiguation,omitempty"`
+	Description    string             `xml:"description,omitempty"`
+	Aliases        []*Alias           `xml:"alias-list>alias"`
+	Tags           []*Tag             `xml:"tag-list>tag"`
+	Relations      TargetRelationsMap `xml:"relation-list"`
+}
+
+// MarshalXML omits the alias-list and tag-list if they are empty.
+func (mbe Instrument) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+
+	type instrument Instrument
+	res := struct {
+		*instrument
+		Aliases *xmlList[*Alias] `xml:"alias-list"`
+		Tags    *xmlList[*Tag]   `xml:"tag-list"`
+	}{
+		instrument: (*instrument)(&mbe),
+		Aliases:    newXMLList("alias", mbe.Aliases),
+		Tags:       newXMLList("tag", mbe.Tags),
+	}
+
+	return e.EncodeElement(res, start)
+}
+
+func (mbe *Instrument) lookupResult() interface{} {
+	return &lookupDoc{mbe}
+}
+
+func (mbe *Instrument) apiEndpoint() string {
+	return "/instrument"
+}
+
+func (mbe *Instrument) Id() MBID {
+	return mbe.ID
+}
+
+// LookupInstrument performs an instrument lookup request for the given MBID.
+func (c *WS2Client) LookupInstrument(id MBID, inc ...string) (*Instrument, error) {
+	return Lookup[*Instrument](context.Background(), c, id, inc...)
+}
+
+// SearchInstrument queries MusicBrainz´ Search Server for Instruments.
+//
+// Possible search fields to provide in searchTerm are:
+//
+//	alias        the aliases/misspellings for this instrument
+//	comment      disambiguation comment
+//	description  description of the instrument
+//	iid          MBID of the instrument
+//	instrument   name of the instrument
+//	tag          folksonomy tag
+//	type         instrument type
+//
+// With no fields specified searchTerm searches the instrument, alias and
+// description fields. For more information visit
+// https://musicbrainz.org/doc/Development/XML_Web_Service/Version_2/Search#Instrument
+func (c *WS2Client) SearchInstrument(searchTerm string, limit, offset int) (*SearchResponse[*Instrument], error) {
+	return Search[*Instrument](context.Background(), c, searchTerm, limit, offset)
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSearchInstrument(t *testing.T) {
+
+	want := SearchResponse[*Instrument]{
+		WS2ListResponse: WS2ListResponse{
+			Count:  2,
+			Offset: 0,
+		},
+		Results: []Scored[*Instrument]{
+			{
+				Entity: &Instrument{
+					ID:          "7ee8ebf5-3aed-4fc8-8004-49f4a8c45a87",
+					Type:        "Electronic instrument",
+					TypeID:      "cc00f97f-1144-3669-b7d5-28bb6b2e2e61",
+					Name:        "theremin",
+					Description: "Early electronic instrument played without physical contact.",
+					Aliases: []*Alias{
+						{Name: "thereminvox", SortName: "thereminvox"},
+					},
+				},
+				Score: 100,
+			},
+			{
+				Entity: &Instrument{
+					ID:             "d1a7b1b3-8d04-4a8d-9b5d-23a3b9a1f0b8",
+					Type:           "Electronic instrument",
+					TypeID:         "cc00f97f-1144-3669-b7d5-28bb6b2e2e61",
+					Name:           "electro-theremin",
+					Disambiguation: "Tannerin",
+				},
+				Score: 62,
+			},
+		},
+	}
+
+	setupHTTPTesting()
+	defer server.Close()
+	serveTestFile("/instrument", "SearchInstrument.xml", t)
+
+	returned, err := client.SearchInstrument("theremin", -1, -1)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(*returned, want) {
+		t.Error(requestDiff(&want, returned))
+	}
+}
@@ -25,11 +25,14 @@
 
 package gomusicbrainz
 
-import "encoding/xml"
+import (
+	"context"
+	"encoding/xml"
+)
 
 // LabelInfo contains a label and links it to a catalog number.
 type LabelInfo struct {
-	CatalogNumber string `xml:"catalog-number"`
+	CatalogNumber string `xml:"catalog-number,omitempty"`
 	Label         *Label `xml:"label"`
 }
 
@@ -37,25 +40,38 @@ type LabelInfo struct {
 // mainly to imprints in MusicBrainz. Visit https://musicbrainz.org/doc/Label
 // for more information.
 type Label struct {
-	ID             MBID     `xml:"id,attr"`
-	Name           string   `xml:"name"`
-	Type           string   `xml:"type,attr"`
-	SortName       string   `xml:"sort-name"`
-	Disambiguation string   `xml:"disambiguation"`
-	CountryCode    string   `xml:"country"`
-	Area           Area     `xml:"area"`
-	LabelCode      int      `xml:"label-code"`
-	Lifespan       Lifespan `xml:"life-span"`
-	Aliases        []*Alias `xml:"alias-list>alias"`
+	ID             MBID      `xml:"id,attr"`
+	Name           string    `xml:"name,omitempty"`
+	Type           LabelType `xml:"type,attr,omitempty"`
+	TypeID         MBID      `xml:"type-id,attr,omitempty"`
+	SortName       string    `xml:"sort-name,omitempty"`
+	Disambiguation string    `xml:"disambiguation,omitempty"`
+	CountryCode    string    `xml:"country,omitempty"`
+	Area           Area      `xml:"area"`
+	LabelCode      int       `xml:"label-code,omitempty"`
+	Lifespan       Lifespan  `xml:"life-span"`
+	Aliases        []*Alias  `xml:"alias-list>alias"`
+	Rating         Rating    `xml:"rating"`
+	UserRating     int       `xml:"user-rating,omitempty"`
 }
 
-func (mbe *Label) lookupResult() interface{} {
-	var res struct {
-		XMLName xml.Name `xml:"metadata"`
-		Ptr     *Label   `xml:"label"`
+// MarshalXML omits the alias-list if it is empty.
+func (mbe Label) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+
+	type label Label
+	res := struct {
+		*label
+		Aliases *xmlList[*Alias] `xml:"alias-list"`
+	}{
+		label:   (*label)(&mbe),
+		Aliases: newXMLList("alias", mbe.Aliases),
 	}
-	res.Ptr = mbe
-	return &res
+
+	return e.EncodeElement(res, start)
+}
+
+func (mbe *Label) lookupResult() interface{} {
+	return &lookupDoc{mbe}
 }
 
 func (mbe *Label) apiEndpoint() string {
@@ -99,45 +115,25 @@ func (c *WS2Client) LookupLabel(id MBID, inc ...string) (*Label, error) {
 // https://musicbrainz.org/doc/Development/XML_Web_Service/Version_2/Search#Label
 func (c *WS2Client) SearchLabel(searchTerm string, limit, offset int) (*LabelSearchResponse, error) {
 
-	result := labelListResult{}
-	err := c.searchRequest("/label", &result, searchTerm, limit, offset)
+	result, err := Search[*Label](context.Background(), c, searchTerm, limit, offset)
 
-	rsp := LabelSearchResponse{}
-	rsp.WS2ListResponse = result.LabelList.WS2ListResponse
-	rsp.Scores = make(ScoreMap)
-
-	for i, v := range result.LabelList.Labels {
-		rsp.Labels = append(rsp.Labels, v.Label)
-		rsp.Scores[rsp.Labels[i]] = v.Score
-	}
-
-	return &rsp, err
+	return &LabelSearchResponse{
+		WS2ListResponse: result.WS2ListResponse,
+		Labels:          Entities(result.Results),
+		Scores:          scoreMap(result.Results),
+		Results:         result.Results,
+	}, err
 }
 
 // LabelSearchResponse is the response type returned by the SearchLabel method.
 type LabelSearchResponse struct {
 	WS2ListResponse
-	Labels []*Label
-	Scores ScoreMap
+	Labels  []*Label
+	Scores  ScoreMap
+	Results []Scored[*Label]
 }
 
 // ResultsWithScore returns a slice of Labels with a min score.
 func (r *LabelSearchResponse) ResultsWithScore(score int) []*Label {
-	var res []*Label
-	for _, v := range r.Labels {
-		if r.Scores[v] >= score {
-			res = append(res, v)
-		}
-	}
-	return res
-}
-
-type labelListResult struct {
-	LabelList struct {
-		WS2ListResponse
-		Labels []struct {
-			*Label
-			Score int `xml:"http://musicbrainz.org/ns/ext#-2.0 score,attr"`
-		} `xml:"label"`
-	} `xml:"label-list"`
+	return Entities(FilterByScore(r.Results, score))
 }
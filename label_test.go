@@ -83,6 +83,7 @@ func TestSearchLabel(t *testing.T) {
 	want.Scores = ScoreMap{
 		returned.Labels[0]: 100,
 	}
+	want.Results = []Scored[*Label]{{Entity: returned.Labels[0], Score: 100}}
 
 	if !reflect.DeepEqual(*returned, want) {
 		t.Error(requestDiff(&want, returned))
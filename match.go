@@ -0,0 +1,434 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Weights of the properties compared by MatchReleases. Properties which are
+// not set in the MatchQuery are ignored.
+const (
+	matchWeightTracks     = 40 // per-track title, length and artist
+	matchWeightTrackCount = 20
+	matchWeightTitle      = 15
+	matchWeightArtist     = 15
+	matchWeightDate       = 5
+	matchWeightCountry    = 5
+	matchWeightFormat     = 5
+)
+
+// matchMaxLengthDiff is the track length difference at which a track's length
+// similarity drops to 0.
+const matchMaxLengthDiff = 15 * time.Second
+
+// MatchQuery describes a set of audio files, e.g. an album folder, to find the
+// release of. All fields are optional, but at least Album, Artist or a track
+// title should be set for a meaningful search.
+type MatchQuery struct {
+	Album   string
+	Artist  string // album artist
+	Year    int
+	Country string // two letter country code e.g. "GB"
+	Format  string // medium format e.g. "CD", "Vinyl"
+	Tracks  []MatchTrack
+}
+
+// MatchTrack is the metadata of a single audio file.
+type MatchTrack struct {
+	Title  string
+	Artist string
+	Length time.Duration
+}
+
+// MatchOptions configures MatchReleases.
+type MatchOptions struct {
+	// Candidates is the number of releases found by the searches which are
+	// looked up and compared track by track (default 5).
+	Candidates int
+}
+
+// ReleaseMatch is a release ranked by MatchReleases.
+type ReleaseMatch struct {
+	Release *Release // with mediums, tracks and artist credits
+
+	// Confidence of the match from 0 (no similarity) to 1 (all properties
+	// of the query match).
+	Confidence float64
+
+	// Tracks holds the track assigned to each track of the query in the
+	// order of MatchQuery.Tracks.
+	Tracks []TrackAssignment
+}
+
+// TrackAssignment is the release track assigned to a track of a MatchQuery.
+// Track and Medium are nil if no track was assigned.
+type TrackAssignment struct {
+	Medium     *Medium
+	Track      *Track
+	Similarity float64 // of title, length and artist from 0 to 1
+}
+
+// MatchReleases searches releases matching q with SearchRelease and
+// SearchRecording, looks up the best candidates and returns them ranked by
+// their similarity to q. Releases are compared by track count, title, length
+// and artist of each track, title, artist credit, date, country and format.
+// Candidates which cannot be looked up are left out, an error is only returned
+// if the searches or all lookups fail. opts may be nil.
+func (c *WS2Client) MatchReleases(ctx context.Context, q MatchQuery, opts *MatchOptions) ([]*ReleaseMatch, error) {
+
+	n := 5
+	if opts != nil && opts.Candidates > 0 {
+		n = opts.Candidates
+	}
+
+	ids, err := c.matchCandidates(ctx, q, n)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]BatchItem, len(ids))
+	for i, id := range ids {
+		items[i] = BatchItem{
+			Entity: &Release{ID: id},
+			Inc:    []string{"recordings", "artist-credits", "media"},
+		}
+	}
+
+	// a candidate which cannot be looked up is skipped, the others may
+	// still match
+	var matches []*ReleaseMatch
+	for r := range c.BatchLookup(ctx, items, BatchOptions{Ordered: true}) {
+		if r.Err != nil {
+			err = r.Err
+			continue
+		}
+		matches = append(matches, matchRelease(q, r.Entity.(*Release)))
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+	if len(matches) == 0 && err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Confidence > matches[j].Confidence
+	})
+
+	return matches, nil
+}
+
+// matchCandidates returns the MBIDs of up to n releases found by a release
+// search and, if that yields less, by a recording search for the first track.
+func (c *WS2Client) matchCandidates(ctx context.Context, q MatchQuery, n int) ([]MBID, error) {
+
+	var ids []MBID
+	seen := make(map[MBID]bool)
+	add := func(id MBID) {
+		if !seen[id] && len(ids) < n {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	var terms []string
+	if q.Album != "" {
+		terms = append(terms, "release:"+luceneQuote(q.Album))
+	}
+	if q.Artist != "" {
+		terms = append(terms, "artist:"+luceneQuote(q.Artist))
+	}
+	if len(terms) > 0 {
+		resp, err := Search[*Release](ctx, c, strings.Join(terms, " AND "), 25, -1)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resp.Results {
+			add(r.Entity.ID)
+		}
+	}
+
+	if len(ids) < n && len(q.Tracks) > 0 && q.Tracks[0].Title != "" {
+		terms = []string{"recording:" + luceneQuote(q.Tracks[0].Title)}
+		if artist := firstNonEmpty(q.Tracks[0].Artist, q.Artist); artist != "" {
+			terms = append(terms, "artist:"+luceneQuote(artist))
+		}
+		resp, err := Search[*Recording](ctx, c, strings.Join(terms, " AND "), 25, -1)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resp.Results {
+			for _, rel := range r.Entity.Releases {
+				add(rel.ID)
+			}
+		}
+	}
+
+	return ids, nil
+}
+
+// matchRelease compares the looked up release r with q.
+func matchRelease(q MatchQuery, r *Release) *ReleaseMatch {
+
+	m := &ReleaseMatch{Release: r}
+
+	var score, weights float64
+	addScore := func(weight int, s float64) {
+		score += float64(weight) * s
+		weights += float64(weight)
+	}
+
+	if len(q.Tracks) > 0 {
+		var sum float64
+		m.Tracks = assignTracks(q.Tracks, r)
+		for _, a := range m.Tracks {
+			sum += a.Similarity
+		}
+		addScore(matchWeightTracks, sum/float64(len(q.Tracks)))
+
+		n := 0
+		for _, medium := range r.Mediums {
+			n += len(medium.Tracks)
+		}
+		addScore(matchWeightTrackCount, ratio(len(q.Tracks), n))
+	}
+
+	if q.Album != "" {
+		addScore(matchWeightTitle, similarity(q.Album, r.Title))
+	}
+
+	if q.Artist != "" {
+		addScore(matchWeightArtist, artistCreditSimilarity(q.Artist, r.ArtistCredit))
+	}
+
+	if q.Year != 0 {
+		s := 0.0
+		if !r.Date.IsZero() {
+			switch d := r.Date.Year() - q.Year; {
+			case d == 0:
+				s = 1
+			case d == 1 || d == -1:
+				s = 0.5
+			}
+		}
+		addScore(matchWeightDate, s)
+	}
+
+	if q.Country != "" {
+		s := 0.0
+		if strings.EqualFold(r.CountryCode, q.Country) {
+			s = 1
+		}
+		addScore(matchWeightCountry, s)
+	}
+
+	if q.Format != "" {
+		s := 0.0
+		for _, medium := range r.Mediums {
+			if strings.EqualFold(medium.Format, q.Format) {
+				s = 1
+				break
+			}
+		}
+		addScore(matchWeightFormat, s)
+	}
+
+	if weights > 0 {
+		m.Confidence = score / weights
+	}
+	return m
+}
+
+// assignTracks assigns the tracks of r to the query tracks, most similar
+// pairs first, so files need not be in release order.
+func assignTracks(tracks []MatchTrack, r *Release) []TrackAssignment {
+
+	type pair struct {
+		query  int
+		medium *Medium
+		track  *Track
+		index  int // of the track on the release
+		sim    float64
+	}
+
+	var pairs []pair
+	index := 0
+	for _, medium := range r.Mediums {
+		for _, t := range medium.Tracks {
+			for i, qt := range tracks {
+				pairs = append(pairs, pair{i, medium, t, index, trackSimilarity(qt, t)})
+			}
+			index++
+		}
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].sim > pairs[j].sim
+	})
+
+	assigned := make([]TrackAssignment, len(tracks))
+	queryDone := make([]bool, len(tracks))
+	trackDone := make([]bool, index)
+
+	for _, p := range pairs {
+		if p.sim <= 0 || queryDone[p.query] || trackDone[p.index] {
+			continue
+		}
+		queryDone[p.query], trackDone[p.index] = true, true
+		assigned[p.query] = TrackAssignment{Medium: p.medium, Track: p.track, Similarity: p.sim}
+	}
+
+	return assigned
+}
+
+// trackSimilarity compares the title, length and artist of a query track and
+// a release track. Properties missing on either side are ignored.
+func trackSimilarity(qt MatchTrack, t *Track) float64 {
+
+	title := firstNonEmpty(t.Title, t.Recording.Title)
+	length := t.Length
+	if length == 0 {
+		length = t.Recording.Length
+	}
+	credit := t.ArtistCredit
+	if len(credit.NameCredits) == 0 {
+		credit = t.Recording.ArtistCredit
+	}
+
+	var score, weights float64
+	if qt.Title != "" && title != "" {
+		score += 0.6 * similarity(qt.Title, title)
+		weights += 0.6
+	}
+	if qt.Length > 0 && length > 0 {
+		diff := qt.Length - time.Duration(length)*time.Millisecond
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff < matchMaxLengthDiff {
+			score += 0.4 * (1 - float64(diff)/float64(matchMaxLengthDiff))
+		}
+		weights += 0.4
+	}
+	if qt.Artist != "" && len(credit.NameCredits) > 0 {
+		score += 0.2 * artistCreditSimilarity(qt.Artist, credit)
+		weights += 0.2
+	}
+
+	if weights == 0 {
+		return 0
+	}
+	return score / weights
+}
+
+// artistCreditSimilarity compares name with the credited artists, both joined
+// and individually. Artists are compared by the name they are credited as,
+// which may differ from their artist name.
+func artistCreditSimilarity(name string, ac ArtistCredit) float64 {
+
+	best := similarity(name, ac.Name())
+	for _, nc := range ac.NameCredits {
+		if s := similarity(name, firstNonEmpty(nc.Name, nc.Artist.Name)); s > best {
+			best = s
+		}
+	}
+	return best
+}
+
+// similarity returns the similarity of a and b from 0 to 1 based on the
+// Levenshtein distance of their normalized forms.
+func similarity(a, b string) float64 {
+
+	ra, rb := []rune(normalizeTitle(a)), []rune(normalizeTitle(b))
+	if len(ra) == 0 && len(rb) == 0 {
+		return 1
+	}
+
+	return 1 - float64(levenshtein(ra, rb))/float64(max(len(ra), len(rb)))
+}
+
+// normalizeTitle returns s in lower case with punctuation and diacritics
+// removed and white space collapsed.
+func normalizeTitle(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			return unicode.ToLower(r)
+		case unicode.IsSpace(r):
+			return ' '
+		}
+		return -1
+	}, foldDiacritics(s))
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// levenshtein returns the edit distance of a and b.
+func levenshtein(a, b []rune) int {
+
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// ratio returns the ratio of the smaller to the larger of a and b.
+func ratio(a, b int) float64 {
+	if a == b {
+		return 1
+	}
+	if a > b {
+		a, b = b, a
+	}
+	return float64(a) / float64(b)
+}
+
+func firstNonEmpty(s ...string) string {
+	for _, v := range s {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
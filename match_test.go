@@ -0,0 +1,252 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const (
+	matchAlbum       = "3f2b7c1e-8d4a-4b6f-9e21-5c7a0d9b1e42"
+	matchDigital     = "8a61d0f5-2c3e-4f7b-b1d9-0e4c6a2f7b13"
+	matchCompilation = "c47e9a20-5b18-4d63-a0f2-9b3d1e6c8f57"
+)
+
+func serveMatchTestFiles(t *testing.T) {
+	serveTestFile("/release", "MatchSearchRelease.xml", t)
+	serveTestFile("/recording", "MatchSearchRecording.xml", t)
+	for _, id := range []string{matchAlbum, matchDigital, matchCompilation} {
+		serveTestFile("/release/"+id, "MatchLookupRelease-"+id+".xml", t)
+	}
+}
+
+func TestMatchReleases(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+	serveMatchTestFiles(t)
+
+	// files are not in release order and have slightly different lengths
+	q := MatchQuery{
+		Album:   "Signals from the Deep",
+		Artist:  "Lanterns",
+		Year:    2003,
+		Country: "GB",
+		Format:  "CD",
+		Tracks: []MatchTrack{
+			{Title: "Harbor Lights", Length: 241 * time.Second},
+			{Title: "Signals", Length: 200 * time.Second},
+			{Title: "Undertow", Length: 305 * time.Second},
+		},
+	}
+
+	matches, err := client.MatchReleases(context.Background(), q, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(matches) != 3 {
+		t.Fatalf("got %d matches, want 3", len(matches))
+	}
+	for i, want := range []MBID{matchAlbum, matchDigital, matchCompilation} {
+		if got := matches[i].Release.ID; got != want {
+			t.Errorf("match %d is %s, want %s", i, got, want)
+		}
+	}
+
+	best := matches[0]
+	if best.Confidence < 0.9 || best.Confidence > 1 {
+		t.Errorf("got confidence %f for best match", best.Confidence)
+	}
+	if matches[1].Confidence >= best.Confidence || matches[2].Confidence >= matches[1].Confidence {
+		t.Errorf("matches not ranked by confidence: %f, %f, %f",
+			best.Confidence, matches[1].Confidence, matches[2].Confidence)
+	}
+
+	for i, want := range []MBID{
+		"1f0e5d2b-8c3a-4b49-ad76-e2a9f3b4c5d6",
+		"0e9d4c1a-7b2f-4a38-9c65-d1f8e2a3b4c5",
+		"2a1f6e3c-9d4b-4c5a-be87-f3bae4c5d6e7",
+	} {
+		a := best.Tracks[i]
+		if a.Track == nil || a.Track.ID != want {
+			t.Errorf("query track %d assigned %+v, want track %s", i, a.Track, want)
+			continue
+		}
+		if a.Medium != best.Release.Mediums[0] {
+			t.Errorf("query track %d has wrong medium", i)
+		}
+	}
+
+	// only one of the tracks is on the compilation
+	assigned := 0
+	for _, a := range matches[2].Tracks {
+		if a.Track != nil && a.Similarity > 0.5 {
+			assigned++
+		}
+	}
+	if assigned != 1 {
+		t.Errorf("got %d similar tracks on the compilation, want 1", assigned)
+	}
+}
+
+func TestMatchReleasesCandidates(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+	serveMatchTestFiles(t)
+
+	// the release search alone fills the candidates
+	matches, err := client.MatchReleases(context.Background(), MatchQuery{
+		Album:  "Signals from the Deep",
+		Tracks: []MatchTrack{{Title: "Harbour Lights"}},
+	}, &MatchOptions{Candidates: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 2 {
+		t.Errorf("got %d matches, want 2", len(matches))
+	}
+
+	// without album and artist only the recording search is used
+	matches, err = client.MatchReleases(context.Background(), MatchQuery{
+		Tracks: []MatchTrack{{Title: "Harbour Lights", Length: 240 * time.Second}},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("got %d matches, want 2", len(matches))
+	}
+	for _, m := range matches {
+		if m.Release.ID == matchAlbum {
+			t.Error("unexpected match of release search")
+		}
+	}
+	if m := matches[0]; m.Release.ID != matchCompilation {
+		t.Errorf("got %s, want %s with matching track count", m.Release.ID, matchCompilation)
+	}
+}
+
+func TestMatchReleasesLookupFailed(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+	serveTestFile("/release", "MatchSearchRelease.xml", t)
+	serveTestFile("/recording", "MatchSearchRecording.xml", t)
+	serveTestFile("/release/"+matchAlbum, "MatchLookupRelease-"+matchAlbum+".xml", t)
+	serveTestFile("/release/"+matchCompilation, "MatchLookupRelease-"+matchCompilation+".xml", t)
+	mux.HandleFunc("/release/"+matchDigital, http.NotFound)
+
+	q := MatchQuery{
+		Album:  "Signals from the Deep",
+		Artist: "Lanterns",
+		Tracks: []MatchTrack{{Title: "Harbor Lights"}},
+	}
+
+	matches, err := client.MatchReleases(context.Background(), q, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("got %d matches, want 2", len(matches))
+	}
+	for _, m := range matches {
+		if m.Release.ID == matchDigital {
+			t.Errorf("got match for release %s which was not found", matchDigital)
+		}
+	}
+
+	// all lookups failing is an error
+	setupHTTPTesting()
+	defer server.Close()
+	serveTestFile("/release", "MatchSearchRelease.xml", t)
+	serveTestFile("/recording", "MatchSearchRecording.xml", t)
+	mux.HandleFunc("/release/", http.NotFound)
+
+	if _, err := client.MatchReleases(context.Background(), q, nil); err == nil {
+		t.Error("expected error if no candidate can be looked up")
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+
+	for _, tc := range []struct {
+		a, b string
+		want float64
+	}{
+		{"Harbour Lights", "harbour lights!", 1},
+		{"", "", 1},
+		{"abc", "", 0},
+		{"Harbor Lights", "Harbour Lights", 1 - 1.0/14},
+		{"Kitten", "Sitting", 1 - 3.0/7},
+	} {
+		if got := similarity(tc.a, tc.b); math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("similarity(%q, %q) = %f, want %f", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestArtistCreditSimilarity(t *testing.T) {
+
+	ac := ArtistCredit{NameCredits: []NameCredit{
+		{Artist: Artist{Name: "Prince Rogers Nelson"}, Name: "Prince", JoinPhrase: " & "},
+		{Artist: Artist{Name: "The Revolution"}},
+	}}
+
+	for _, tc := range []struct {
+		name string
+		want float64
+	}{
+		{"Prince", 1},
+		{"The Revolution", 1},
+		{"Prince & The Revolution", 1},
+	} {
+		if got := artistCreditSimilarity(tc.name, ac); math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("artistCreditSimilarity(%q) = %f, want %f", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestTrackSimilarityArtist(t *testing.T) {
+
+	track := &Track{
+		Title: "Harbour Lights",
+		Recording: Recording{ArtistCredit: ArtistCredit{NameCredits: []NameCredit{
+			{Artist: Artist{Name: "Lanterns"}, Name: "The Lanterns"},
+		}}},
+	}
+
+	same := trackSimilarity(MatchTrack{Title: "Harbour Lights", Artist: "The Lanterns"}, track)
+	other := trackSimilarity(MatchTrack{Title: "Harbour Lights", Artist: "Kit North"}, track)
+	if same != 1 || other >= same {
+		t.Errorf("got similarity %f for the credited artist and %f for another one", same, other)
+	}
+}
@@ -0,0 +1,314 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// mirrorCooldown is the time a failed mirror is skipped. It doubles with
+	// every consecutive failure up to mirrorMaxCooldown.
+	mirrorCooldown    = 10 * time.Second
+	mirrorMaxCooldown = 5 * time.Minute
+
+	// throttleRetries is the number of times a request answered with 503,
+	// which MusicBrainz uses to reject requests exceeding its rate limit, is
+	// retried on the same mirror. The wait before each retry is given by the
+	// Retry-After header or starts at throttleBackoff and doubles, at most
+	// throttleMaxWait.
+	throttleRetries = 3
+	throttleBackoff = time.Second
+	throttleMaxWait = time.Minute
+)
+
+// Mirror is a WS2 server used by a WS2Client, e.g. a self-hosted MusicBrainz
+// mirror or musicbrainz.org itself.
+type Mirror struct {
+	URL       string        // root URL, "/ws/2" is appended if missing
+	RateLimit time.Duration // minimum interval between requests, 0 for none
+}
+
+// MirrorStatus describes the health of a mirror as tracked by the WS2Client.
+type MirrorStatus struct {
+	URL       string
+	Healthy   bool
+	Failures  int       // consecutive failed requests
+	DownUntil time.Time // the mirror is skipped until then
+	LastError error
+}
+
+// SetMirrors makes c send its requests to the given mirrors instead of
+// WS2RootURL, which is left unchanged. Requests go to the first healthy mirror
+// and fail over to the next one on connection errors and 5xx responses other
+// than 503. A 503 means the request was throttled, it is retried on the same
+// mirror after a backoff and only then sent to the next mirror, without
+// marking the throttling mirror as failed. A failed mirror is skipped for a
+// cooldown which grows with its consecutive failures, so c sticks with a
+// working mirror until the preferred ones have recovered. Each mirror has its
+// own rate limit, e.g.
+//
+//	client.SetMirrors(
+//		Mirror{URL: "http://mirror.local:5000"},
+//		Mirror{URL: "https://musicbrainz.org", RateLimit: DefaultRateLimit},
+//	)
+//
+// Calling SetMirrors without mirrors restores the use of WS2RootURL.
+func (c *WS2Client) SetMirrors(mirrors ...Mirror) error {
+
+	list := make([]*mirror, len(mirrors))
+	for i, m := range mirrors {
+		base, err := parseRootURL(m.URL)
+		if err != nil {
+			return err
+		}
+		list[i] = &mirror{base: base}
+		if m.RateLimit > 0 {
+			list[i].limiter = &rateLimiter{interval: m.RateLimit}
+		}
+	}
+
+	c.mirrors.mu.Lock()
+	c.mirrors.list = list
+	c.mirrors.mu.Unlock()
+
+	return nil
+}
+
+// MirrorStatus returns the health of the mirrors set with SetMirrors in the
+// configured order.
+func (c *WS2Client) MirrorStatus() []MirrorStatus {
+
+	c.mirrors.mu.Lock()
+	defer c.mirrors.mu.Unlock()
+
+	now := time.Now()
+	status := make([]MirrorStatus, len(c.mirrors.list))
+	for i, m := range c.mirrors.list {
+		status[i] = MirrorStatus{
+			URL:       m.base.String(),
+			Healthy:   !now.Before(m.downUntil),
+			Failures:  m.failures,
+			DownUntil: m.downUntil,
+			LastError: m.lastErr,
+		}
+	}
+	return status
+}
+
+// parseRootURL parses the root URL of a WS2 server and appends "ws/2" to its
+// path if missing.
+func parseRootURL(s string) (*url.URL, error) {
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(u.Path, "ws/2") {
+		u.Path = path.Join(u.Path, "ws/2")
+	}
+	return u, nil
+}
+
+// mirror is a WS2 server with its rate limit and health.
+type mirror struct {
+	base    *url.URL
+	limiter *rateLimiter
+
+	// guarded by mirrorSet.mu
+	failures  int
+	downUntil time.Time
+	lastErr   error
+}
+
+// do sends req as soon as the rate limit of m allows it.
+func (m *mirror) do(req *http.Request) (*http.Response, error) {
+	if err := m.limiter.wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return newHTTPClient().Do(req)
+}
+
+// mirrorSet tracks the health of the mirrors of a WS2Client.
+type mirrorSet struct {
+	mu   sync.Mutex
+	list []*mirror
+}
+
+// order returns the mirrors in the order they should be tried: healthy ones
+// as configured, followed by failed ones by the end of their cooldown.
+func (s *mirrorSet) order() []*mirror {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	var healthy, down []*mirror
+	for _, m := range s.list {
+		if now.Before(m.downUntil) {
+			down = append(down, m)
+		} else {
+			healthy = append(healthy, m)
+		}
+	}
+	sort.SliceStable(down, func(i, j int) bool {
+		return down[i].downUntil.Before(down[j].downUntil)
+	})
+	return append(healthy, down...)
+}
+
+// report records the outcome of a request to m.
+func (s *mirrorSet) report(m *mirror, err error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err == nil {
+		m.failures = 0
+		m.downUntil = time.Time{}
+		m.lastErr = nil
+		return
+	}
+
+	m.failures++
+	cooldown := mirrorCooldown
+	for i := 1; i < m.failures && cooldown < mirrorMaxCooldown; i++ {
+		cooldown *= 2
+	}
+	if cooldown > mirrorMaxCooldown {
+		cooldown = mirrorMaxCooldown
+	}
+	m.downUntil = time.Now().Add(cooldown)
+	m.lastErr = err
+}
+
+// endpoints returns the mirrors to try for a request. Without mirrors,
+// WS2RootURL is used with the rate limit set by SetRateLimit.
+func (c *WS2Client) endpoints() []*mirror {
+	if ms := c.mirrors.order(); len(ms) > 0 {
+		return ms
+	}
+	return []*mirror{{base: c.WS2RootURL, limiter: c.limiter}}
+}
+
+// send performs a request against the mirrors of c, retries it on 503 and
+// fails over to the next mirror on connection errors and other 5xx responses.
+// It returns the response, the mirror which answered it and the number of
+// retries and failovers. If all mirrors answered with 5xx the last response is
+// returned.
+func (c *WS2Client) send(ctx context.Context, method string, params url.Values, endpoint string, body []byte) (*http.Response, *mirror, int, error) {
+
+	var lastErr error
+	retries := 0
+	ms := c.endpoints()
+
+	for i, m := range ms {
+		if i > 0 {
+			retries++
+		}
+
+		for attempt := 0; ; attempt++ {
+			req, err := c.newRequest(ctx, m.base, method, params, endpoint, body)
+			if err != nil {
+				return nil, nil, retries, err
+			}
+
+			resp, err := m.do(req)
+			if err != nil {
+				if ctxErr := ctx.Err(); ctxErr != nil {
+					return nil, nil, retries, ctxErr
+				}
+				c.mirrors.report(m, err)
+				lastErr = err
+				break
+			}
+
+			switch {
+			case resp.StatusCode == http.StatusServiceUnavailable && attempt < throttleRetries:
+				io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+				if err := sleepContext(ctx, throttleWait(resp, attempt)); err != nil {
+					return nil, nil, retries, err
+				}
+				retries++
+				continue
+			case resp.StatusCode == http.StatusServiceUnavailable:
+				// still throttled, but the mirror works
+			case resp.StatusCode >= 500:
+				c.mirrors.report(m, &WS2Error{StatusCode: resp.StatusCode})
+			default:
+				c.mirrors.report(m, nil)
+				return resp, m, retries, nil
+			}
+
+			if i == len(ms)-1 {
+				return resp, m, retries, nil
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			break
+		}
+	}
+
+	if lastErr == nil {
+		lastErr = errors.New("no mirror available.")
+	}
+	return nil, nil, retries, lastErr
+}
+
+// throttleWait returns the time to wait before retrying a request which was
+// answered with 503 for the given attempt.
+func throttleWait(resp *http.Response, attempt int) time.Duration {
+
+	if s, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && s >= 0 {
+		return min(time.Duration(s)*time.Second, throttleMaxWait)
+	}
+	return min(throttleBackoff<<attempt, throttleMaxWait)
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,225 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newMirrorServer returns a test server answering lookups of the test artist
+// with LookupArtist.xml or with status if it is not 200. Throttling responses
+// ask the client to retry immediately.
+func newMirrorServer(status *int32, hits *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		if s := int(atomic.LoadInt32(status)); s != http.StatusOK {
+			if s == http.StatusServiceUnavailable {
+				w.Header().Set("Retry-After", "0")
+			}
+			http.Error(w, http.StatusText(s), s)
+			return
+		}
+		if r.URL.Path != "/ws/2/artist/10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8" {
+			http.NotFound(w, r)
+			return
+		}
+		http.ServeFile(w, r, path.Join("./testdata", "LookupArtist.xml"))
+	}))
+}
+
+func TestMirrorFailover(t *testing.T) {
+
+	primaryStatus, publicStatus := int32(http.StatusInternalServerError), int32(http.StatusOK)
+	var primaryHits, publicHits int32
+
+	primary := newMirrorServer(&primaryStatus, &primaryHits)
+	defer primary.Close()
+	public := newMirrorServer(&publicStatus, &publicHits)
+	defer public.Close()
+
+	c, _ := NewWS2Client("http://unused.invalid", "Application Name", "Version", "Contact")
+	if err := c.SetMirrors(Mirror{URL: primary.URL}, Mirror{URL: public.URL}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := c.WS2RootURL.String(), "http://unused.invalid/ws/2"; got != want {
+		t.Errorf("WS2RootURL is %s, want %s", got, want)
+	}
+
+	if _, err := c.LookupArtist("10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8"); err != nil {
+		t.Fatal(err)
+	}
+	if primaryHits != 1 || publicHits != 1 {
+		t.Errorf("got %d/%d requests, want 1/1", primaryHits, publicHits)
+	}
+
+	status := c.MirrorStatus()
+	if status[0].Healthy || status[0].Failures != 1 || status[0].LastError == nil {
+		t.Errorf("primary mirror should be unhealthy, got %+v", status[0])
+	}
+	if !status[1].Healthy {
+		t.Errorf("public mirror should be healthy, got %+v", status[1])
+	}
+
+	// the failed mirror is skipped during its cooldown
+	if _, err := c.LookupArtist("10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8"); err != nil {
+		t.Fatal(err)
+	}
+	if primaryHits != 1 || publicHits != 2 {
+		t.Errorf("got %d/%d requests, want 1/2", primaryHits, publicHits)
+	}
+
+	// and preferred again once it recovered
+	atomic.StoreInt32(&primaryStatus, http.StatusOK)
+	c.mirrors.mu.Lock()
+	c.mirrors.list[0].downUntil = time.Now()
+	c.mirrors.mu.Unlock()
+
+	if _, err := c.LookupArtist("10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8"); err != nil {
+		t.Fatal(err)
+	}
+	if primaryHits != 2 || publicHits != 2 {
+		t.Errorf("got %d/%d requests, want 2/2", primaryHits, publicHits)
+	}
+	if s := c.MirrorStatus()[0]; !s.Healthy || s.Failures != 0 {
+		t.Errorf("primary mirror should be healthy, got %+v", s)
+	}
+}
+
+func TestMirrorThrottled(t *testing.T) {
+
+	primaryStatus, publicStatus := int32(http.StatusServiceUnavailable), int32(http.StatusOK)
+	var primaryHits, publicHits int32
+
+	primary := newMirrorServer(&primaryStatus, &primaryHits)
+	defer primary.Close()
+	public := newMirrorServer(&publicStatus, &publicHits)
+	defer public.Close()
+
+	c, _ := NewWS2Client("http://unused.invalid", "Application Name", "Version", "Contact")
+	c.SetMirrors(Mirror{URL: primary.URL, RateLimit: time.Millisecond}, Mirror{URL: public.URL})
+
+	if _, err := c.LookupArtist("10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8"); err != nil {
+		t.Fatal(err)
+	}
+	if want := int32(throttleRetries + 1); primaryHits != want || publicHits != 1 {
+		t.Errorf("got %d/%d requests, want %d/1", primaryHits, publicHits, want)
+	}
+	if s := c.MirrorStatus()[0]; !s.Healthy || s.Failures != 0 {
+		t.Errorf("throttling mirror should stay healthy, got %+v", s)
+	}
+
+	// a throttled request succeeds once the mirror answers again
+	atomic.StoreInt32(&primaryHits, 0)
+	c.SetMirrors(Mirror{URL: primary.URL, RateLimit: time.Millisecond})
+	go func() {
+		for atomic.LoadInt32(&primaryHits) == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		atomic.StoreInt32(&primaryStatus, http.StatusOK)
+	}()
+
+	if _, err := c.LookupArtist("10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMirrorReset(t *testing.T) {
+
+	status := int32(http.StatusOK)
+	var mirrorHits, rootHits int32
+
+	mirror := newMirrorServer(&status, &mirrorHits)
+	defer mirror.Close()
+	root := newMirrorServer(&status, &rootHits)
+	defer root.Close()
+
+	c, _ := NewWS2Client(root.URL, "Application Name", "Version", "Contact")
+	c.SetMirrors(Mirror{URL: mirror.URL})
+	if _, err := c.LookupArtist("10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8"); err != nil {
+		t.Fatal(err)
+	}
+
+	c.SetMirrors()
+	if got, want := c.WS2RootURL.String(), root.URL+"/ws/2"; got != want {
+		t.Errorf("WS2RootURL is %s, want %s", got, want)
+	}
+	if _, err := c.LookupArtist("10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8"); err != nil {
+		t.Fatal(err)
+	}
+	if mirrorHits != 1 || rootHits != 1 {
+		t.Errorf("got %d/%d requests, want 1/1", mirrorHits, rootHits)
+	}
+}
+
+func TestMirrorFailoverConnectionError(t *testing.T) {
+
+	status := int32(http.StatusOK)
+	var hits int32
+	public := newMirrorServer(&status, &hits)
+	defer public.Close()
+
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	c, _ := NewWS2Client("http://unused.invalid", "Application Name", "Version", "Contact")
+	c.SetMirrors(Mirror{URL: down.URL}, Mirror{URL: public.URL, RateLimit: time.Millisecond})
+
+	if _, err := c.LookupArtist("10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8"); err != nil {
+		t.Fatal(err)
+	}
+	if s := c.MirrorStatus()[0]; s.Healthy || s.LastError == nil {
+		t.Errorf("closed mirror should be unhealthy, got %+v", s)
+	}
+}
+
+func TestMirrorFailoverAllFailed(t *testing.T) {
+
+	status := int32(http.StatusBadGateway)
+	var hits int32
+	a := newMirrorServer(&status, &hits)
+	defer a.Close()
+	b := newMirrorServer(&status, &hits)
+	defer b.Close()
+
+	c, _ := NewWS2Client("http://unused.invalid", "Application Name", "Version", "Contact")
+	c.SetMirrors(Mirror{URL: a.URL}, Mirror{URL: b.URL})
+
+	_, err := c.LookupArtist("10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8")
+
+	var ws2Err *WS2Error
+	if !errors.As(err, &ws2Err) || ws2Err.StatusCode != http.StatusBadGateway {
+		t.Errorf("got error %v, want WS2Error with status 502", err)
+	}
+	if hits != 2 {
+		t.Errorf("got %d requests, want 2", hits)
+	}
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// MarshalMMD encodes an entity e.g. an *Artist as MusicBrainz XML Metadata
+// (MMD) document as returned by WS2 lookup requests. It can be used to store
+// entities, which can be decoded again with UnmarshalMMD.
+func MarshalMMD(v interface{}) ([]byte, error) {
+
+	e, ok := v.(MBSearchEntity)
+	if !ok {
+		return nil, fmt.Errorf("unsupported entity type %T", v)
+	}
+
+	data, err := xml.Marshal(&lookupDoc{e})
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
+
+// UnmarshalMMD decodes the entity of a MMD document e.g. created with
+// MarshalMMD or returned by a WS2 lookup request into v, which must be a
+// pointer to an entity e.g. an *Artist.
+func UnmarshalMMD(data []byte, v interface{}) error {
+
+	e, ok := v.(MBSearchEntity)
+	if !ok {
+		return fmt.Errorf("unsupported entity type %T", v)
+	}
+
+	return xml.Unmarshal(data, &lookupDoc{e})
+}
@@ -0,0 +1,187 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestMMDRoundTrip(t *testing.T) {
+
+	tests := []struct {
+		file   string
+		entity interface{}
+	}{
+		{"LookupArtist.xml", &Artist{}},
+		{"LookupRecordingRatings.xml", &Recording{}},
+		{"LookupRelease.xml", &Release{}},
+		{"LookupReleaseGroup.xml", &ReleaseGroup{}},
+		{"LookupReleaseMediums.xml", &Release{}},
+	}
+
+	for _, test := range tests {
+		data, err := ioutil.ReadFile(path.Join("testdata", test.file))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := test.entity
+		if err := UnmarshalMMD(data, want); err != nil {
+			t.Fatalf("%s: %v", test.file, err)
+		}
+
+		encoded, err := MarshalMMD(want)
+		if err != nil {
+			t.Fatalf("%s: %v", test.file, err)
+		}
+		if !strings.Contains(string(encoded), `<metadata xmlns="`+mmdNamespace+`">`) {
+			t.Errorf("%s: missing metadata element:\n%s", test.file, encoded)
+		}
+
+		returned := reflect.New(reflect.TypeOf(want).Elem()).Interface()
+		if err := UnmarshalMMD(encoded, returned); err != nil {
+			t.Fatalf("%s: %v\n%s", test.file, err, encoded)
+		}
+
+		if !reflect.DeepEqual(want, returned) {
+			t.Error(test.file, requestDiff(want, returned))
+		}
+	}
+}
+
+func TestMMDRoundTripLists(t *testing.T) {
+
+	tests := []struct {
+		file   string
+		result interface{}
+	}{
+		{"SearchAnnotation.xml", &SearchResponse[*Annotation]{}},
+		{"SearchArea.xml", &SearchResponse[*Area]{}},
+		{"SearchArtist.xml", &SearchResponse[*Artist]{}},
+		{"SearchCDStub.xml", &SearchResponse[*CDStub]{}},
+		{"SearchEvent.xml", &SearchResponse[*Event]{}},
+		{"SearchLabel.xml", &SearchResponse[*Label]{}},
+		{"SearchPlace.xml", &SearchResponse[*Place]{}},
+		{"SearchRecording.xml", &SearchResponse[*Recording]{}},
+		{"SearchRelease.xml", &SearchResponse[*Release]{}},
+		{"SearchReleaseGroup.xml", &SearchResponse[*ReleaseGroup]{}},
+		{"SearchWork.xml", &SearchResponse[*Work]{}},
+		{"FindReleases.xml", &SearchResponse[*Release]{}},
+		{"BrowseCollectionReleases.xml", &SearchResponse[*Release]{}},
+		{"UserCollections.xml", &SearchResponse[*Collection]{}},
+	}
+
+	for _, test := range tests {
+		data, err := ioutil.ReadFile(path.Join("testdata", test.file))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := test.result
+		if err := xml.Unmarshal(data, want); err != nil {
+			t.Fatalf("%s: %v", test.file, err)
+		}
+
+		encoded, err := xml.Marshal(want)
+		if err != nil {
+			t.Fatalf("%s: %v", test.file, err)
+		}
+
+		returned := reflect.New(reflect.TypeOf(want).Elem()).Interface()
+		if err := xml.Unmarshal(encoded, returned); err != nil {
+			t.Fatalf("%s: %v\n%s", test.file, err, encoded)
+		}
+
+		if !reflect.DeepEqual(want, returned) {
+			t.Error(test.file, requestDiff(want, returned))
+		}
+	}
+}
+
+func TestMarshalMMD(t *testing.T) {
+
+	a := &Artist{
+		ID:   "10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8",
+		Name: "Massive Attack",
+		Type: ArtistTypeGroup,
+	}
+
+	encoded, err := MarshalMMD(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := xml.Header + `<metadata xmlns="` + mmdNamespace + `">` +
+		`<artist id="10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8" type="Group">` +
+		`<name>Massive Attack</name>` +
+		`</artist></metadata>`
+	if string(encoded) != want {
+		t.Errorf("got\n%s\nwant\n%s", encoded, want)
+	}
+
+	if _, err := MarshalMMD(a.Area); err == nil {
+		t.Error("expected error for non-pointer entity")
+	}
+}
+
+func TestMarshalMMDOmitsEmptyLists(t *testing.T) {
+
+	emptyList := regexp.MustCompile(`<[a-z0-9-]+-list[^>]*></`)
+
+	id := MBID("10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8")
+
+	for _, e := range []MBSearchEntity{
+		&Area{ID: id},
+		&Artist{ID: id, Area: Area{Name: "Bristol"}},
+		&Event{ID: id},
+		&Instrument{ID: id},
+		&Label{ID: id},
+		&Place{ID: id},
+		&Series{ID: id},
+		&Work{ID: id},
+		&Recording{ID: id},
+		&ReleaseGroup{ID: id},
+		&Release{ID: id, Mediums: []*Medium{{
+			Format: "CD",
+			Discs:  []*Disc{{ID: "49HHV7Eb8UKF3aQiNmu1GR8vKTY-"}},
+			Tracks: []*Track{{Title: "Angel"}},
+		}}},
+	} {
+		encoded, err := MarshalMMD(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if emptyList.Match(encoded) {
+			t.Errorf("%T: empty list in %s", e, encoded)
+		}
+	}
+}
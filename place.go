@@ -25,28 +25,42 @@
 
 package gomusicbrainz
 
-import "encoding/xml"
+import (
+	"context"
+	"encoding/xml"
+)
 
 // Place represents a building or outdoor area used for performing or producing
 // music.
 type Place struct {
 	ID          MBID          `xml:"id,attr"`
-	Type        string        `xml:"type,attr"`
-	Name        string        `xml:"name"`
-	Address     string        `xml:"address"`
+	Type        PlaceType     `xml:"type,attr,omitempty"`
+	TypeID      MBID          `xml:"type-id,attr,omitempty"`
+	Name        string        `xml:"name,omitempty"`
+	Address     string        `xml:"address,omitempty"`
 	Coordinates MBCoordinates `xml:"coordinates"`
 	Area        Area          `xml:"area"`
 	Lifespan    Lifespan      `xml:"life-span"`
 	Aliases     []*Alias      `xml:"alias-list>alias"`
 }
 
-func (mbe *Place) lookupResult() interface{} {
-	var res struct {
-		XMLName xml.Name `xml:"metadata"`
-		Ptr     *Place   `xml:"place"`
+// MarshalXML omits the alias-list if it is empty.
+func (mbe Place) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+
+	type place Place
+	res := struct {
+		*place
+		Aliases *xmlList[*Alias] `xml:"alias-list"`
+	}{
+		place:   (*place)(&mbe),
+		Aliases: newXMLList("alias", mbe.Aliases),
 	}
-	res.Ptr = mbe
-	return &res
+
+	return e.EncodeElement(res, start)
+}
+
+func (mbe *Place) lookupResult() interface{} {
+	return &lookupDoc{mbe}
 }
 
 func (mbe *Place) apiEndpoint() string {
@@ -87,45 +101,25 @@ func (c *WS2Client) LookupPlace(id MBID, inc ...string) (*Place, error) {
 // https://musicbrainz.org/doc/Development/XML_Web_Service/Version_2/Search#Place
 func (c *WS2Client) SearchPlace(searchTerm string, limit, offset int) (*PlaceSearchResponse, error) {
 
-	result := placeListResult{}
-	err := c.searchRequest("/place", &result, searchTerm, limit, offset)
+	result, err := Search[*Place](context.Background(), c, searchTerm, limit, offset)
 
-	rsp := PlaceSearchResponse{}
-	rsp.WS2ListResponse = result.PlaceList.WS2ListResponse
-	rsp.Scores = make(ScoreMap)
-
-	for i, v := range result.PlaceList.Places {
-		rsp.Places = append(rsp.Places, v.Place)
-		rsp.Scores[rsp.Places[i]] = v.Score
-	}
-
-	return &rsp, err
+	return &PlaceSearchResponse{
+		WS2ListResponse: result.WS2ListResponse,
+		Places:          Entities(result.Results),
+		Scores:          scoreMap(result.Results),
+		Results:         result.Results,
+	}, err
 }
 
 // PlaceSearchResponse is the response type returned by the SearchPlace method.
 type PlaceSearchResponse struct {
 	WS2ListResponse
-	Places []*Place
-	Scores ScoreMap
+	Places  []*Place
+	Scores  ScoreMap
+	Results []Scored[*Place]
 }
 
 // ResultsWithScore returns a slice of Places with a min score.
 func (r *PlaceSearchResponse) ResultsWithScore(score int) []*Place {
-	var res []*Place
-	for _, v := range r.Places {
-		if r.Scores[v] >= score {
-			res = append(res, v)
-		}
-	}
-	return res
-}
-
-type placeListResult struct {
-	PlaceList struct {
-		WS2ListResponse
-		Places []struct {
-			*Place
-			Score int `xml:"http://musicbrainz.org/ns/ext#-2.0 score,attr"`
-		} `xml:"place"`
-	} `xml:"place-list"`
+	return Entities(FilterByScore(r.Results, score))
 }
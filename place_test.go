@@ -78,6 +78,7 @@ func TestSearchPlace(t *testing.T) {
 	want.Scores = ScoreMap{
 		returned.Places[0]: 100,
 	}
+	want.Results = []Scored[*Place]{{Entity: returned.Places[0], Score: 100}}
 
 	if !reflect.DeepEqual(*returned, want) {
 		t.Error(requestDiff(&want, returned))
@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+)
+
+// Rating represents the community rating of an entity, which is the average
+// of all user ratings on a scale from 0 to 5. Ratings are included with
+// inc=ratings for artists, events, labels, recordings, release groups and
+// works. See https://musicbrainz.org/doc/Rating_System
+type Rating struct {
+	VotesCount int     `xml:"votes-count,attr"`
+	Value      float64 `xml:",chardata"`
+}
+
+// MarshalXML omits empty ratings.
+func (r Rating) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	type rating Rating
+	return encodeOptional(e, rating(r), start)
+}
+
+// RatingSubmission assigns a user rating to an entity. Rating is on a scale
+// from 0 to 100 where 0 removes an existing rating. The MusicBrainz website
+// displays ratings as stars in steps of 20.
+type RatingSubmission struct {
+	Entity MBEntity
+	Rating int
+}
+
+// SubmitRatings submits user ratings for artists, events, labels, recordings,
+// release groups and works. This requires authentication, see SetCredentials.
+// For more information visit
+// https://musicbrainz.org/doc/Development/XML_Web_Service/Version_2#Ratings
+func (c *WS2Client) SubmitRatings(ratings ...RatingSubmission) error {
+
+	body := newSubmissionBody()
+
+	for _, r := range ratings {
+		se, err := body.entity(r.Entity)
+		if err != nil {
+			return err
+		}
+		if r.Rating < 0 || r.Rating > 100 {
+			return fmt.Errorf("rating %d of %s is not in range 0-100", r.Rating, se.ID)
+		}
+		switch r.Entity.(type) {
+		case *Artist, *Event, *Label, *Recording, *ReleaseGroup, *Work:
+		default:
+			return fmt.Errorf("rating %T entities is not supported", r.Entity)
+		}
+
+		rating := r.Rating
+		se.UserRating = &rating
+	}
+
+	return c.authRequest("POST", nil, body, url.Values{"client": {c.clientID}}, "/rating")
+}
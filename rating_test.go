@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLookupRecordingRatings(t *testing.T) {
+
+	want := Recording{
+		ID:     "a2e3c1a4-2c8a-4ad1-9d39-ef6b0ab12ef6",
+		Title:  "Karmacoma",
+		Length: 316266,
+		Rating: Rating{
+			VotesCount: 7,
+			Value:      4.35,
+		},
+		UserRating: 80,
+	}
+
+	setupHTTPTesting()
+	defer server.Close()
+	serveTestFile(
+		"/recording/a2e3c1a4-2c8a-4ad1-9d39-ef6b0ab12ef6",
+		"LookupRecordingRatings.xml", t)
+
+	returned, err := client.LookupRecording(
+		"a2e3c1a4-2c8a-4ad1-9d39-ef6b0ab12ef6",
+		"ratings",
+		"user-ratings")
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(*returned, want) {
+		t.Error(requestDiff(&want, returned))
+	}
+}
+
+func TestSubmitRatings(t *testing.T) {
+
+	want := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		`<metadata xmlns="http://musicbrainz.org/ns/mmd-2.0#">` +
+		`<artist-list>` +
+		`<artist id="10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8"><user-rating>100</user-rating></artist>` +
+		`</artist-list>` +
+		`<recording-list>` +
+		`<recording id="a2e3c1a4-2c8a-4ad1-9d39-ef6b0ab12ef6"><user-rating>0</user-rating></recording>` +
+		`</recording-list>` +
+		`</metadata>`
+
+	setupHTTPTesting()
+	defer server.Close()
+	client.SetCredentials(testUsername, testPassword)
+
+	serveDigestAuth("/rating", t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("expected POST request, got %s", r.Method)
+		}
+		if c := r.URL.Query().Get("client"); c != "Application Name-Version" {
+			t.Errorf("unexpected client parameter %q", c)
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != want {
+			t.Errorf("unexpected request body\nwant: %s\ngot:  %s", want, body)
+		}
+
+		w.Write([]byte(`<metadata><message><text>OK</text></message></metadata>`))
+	})
+
+	err := client.SubmitRatings(
+		RatingSubmission{&Artist{ID: "10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8"}, 100},
+		RatingSubmission{&Recording{ID: "a2e3c1a4-2c8a-4ad1-9d39-ef6b0ab12ef6"}, 0},
+	)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSubmitRatingsInvalid(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+	client.SetCredentials(testUsername, testPassword)
+
+	err := client.SubmitRatings(RatingSubmission{&Artist{ID: "10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8"}, 120})
+	if err == nil || !strings.Contains(err.Error(), "0-100") {
+		t.Errorf("expected range error, got %v", err)
+	}
+
+	err = client.SubmitRatings(RatingSubmission{&Release{ID: "07832b54-8266-47d5-bb0e-62c7f2cf5da5"}, 20})
+	if err == nil {
+		t.Error("expected error for rating a release")
+	}
+}
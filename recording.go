@@ -25,25 +25,84 @@
 
 package gomusicbrainz
 
-import "encoding/xml"
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+)
 
 type Recording struct {
 	ID             MBID         `xml:"id,attr"`
-	Title          string       `xml:"title"`
-	Length         int          `xml:"length"`
-	Disambiguation string       `xml:"disambiguation"`
+	Title          string       `xml:"title,omitempty"`
+	Length         int          `xml:"length,omitempty"`
+	Disambiguation string       `xml:"disambiguation,omitempty"`
 	ArtistCredit   ArtistCredit `xml:"artist-credit"`
+	Rating         Rating       `xml:"rating"`
+	UserRating     int          `xml:"user-rating,omitempty"`
+	ISRCs          []string     `xml:"-"`
+	Releases       []*Release   `xml:"release-list>release"`
 
 	// TODO add refs
 }
 
-func (mbe *Recording) lookupResult() interface{} {
+// UnmarshalXML is needed to implement XMLUnmarshaler for recordings to decode
+// the ISRCs, which are given as id attributes of the isrc elements.
+func (mbe *Recording) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+
+	type recording Recording
 	var res struct {
-		XMLName xml.Name   `xml:"metadata"`
-		Ptr     *Recording `xml:"recording"`
+		*recording
+		ISRCs []struct {
+			ID string `xml:"id,attr"`
+		} `xml:"isrc-list>isrc"`
+	}
+	res.recording = (*recording)(mbe)
+
+	if err := d.DecodeElement(&res, &start); err != nil {
+		return err
+	}
+
+	mbe.ISRCs = nil
+	for _, isrc := range res.ISRCs {
+		mbe.ISRCs = append(mbe.ISRCs, isrc.ID)
+	}
+	return nil
+}
+
+// MarshalXML is the counterpart of UnmarshalXML and encodes the ISRCs. Empty
+// release lists are omitted.
+func (mbe Recording) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+
+	type recording Recording
+	type isrc struct {
+		ID string `xml:"id,attr"`
 	}
-	res.Ptr = mbe
-	return &res
+	type isrcList struct {
+		Count int    `xml:"count,attr"`
+		ISRCs []isrc `xml:"isrc"`
+	}
+	res := struct {
+		*recording
+		ISRCList *isrcList          `xml:"isrc-list"`
+		Releases *xmlList[*Release] `xml:"release-list"`
+	}{
+		recording: (*recording)(&mbe),
+		Releases:  newXMLList("release", mbe.Releases),
+	}
+
+	if len(mbe.ISRCs) > 0 {
+		res.ISRCList = &isrcList{Count: len(mbe.ISRCs)}
+		for _, id := range mbe.ISRCs {
+			res.ISRCList.ISRCs = append(res.ISRCList.ISRCs, isrc{id})
+		}
+	}
+
+	return e.EncodeElement(res, start)
+}
+
+func (mbe *Recording) lookupResult() interface{} {
+	return &lookupDoc{mbe}
 }
 
 func (mbe *Recording) apiEndpoint() string {
@@ -62,6 +121,48 @@ func (c *WS2Client) LookupRecording(id MBID, inc ...string) (*Recording, error)
 	return a, err
 }
 
+// ISRCSubmission adds ISRCs to a recording.
+type ISRCSubmission struct {
+	Recording MBID
+	ISRCs     []string
+}
+
+// SubmitISRCs adds ISRCs to the given recordings. ISRCs are validated and
+// normalized before submission. This requires authentication, see
+// SetCredentials. For more information visit
+// https://musicbrainz.org/doc/Development/XML_Web_Service/Version_2#ISRC_submission
+func (c *WS2Client) SubmitISRCs(isrcs ...ISRCSubmission) error {
+
+	body := newSubmissionBody()
+
+	for _, s := range isrcs {
+		if len(s.ISRCs) == 0 {
+			return fmt.Errorf("no ISRCs for recording %s", s.Recording)
+		}
+
+		se, err := body.entity(&Recording{ID: s.Recording})
+		if err != nil {
+			return err
+		}
+		if se.ISRCList == nil {
+			se.ISRCList = &submissionISRCList{}
+		}
+
+		for _, isrc := range s.ISRCs {
+			n, err := NormalizeISRC(isrc)
+			if err != nil {
+				return err
+			}
+			se.ISRCList.ISRCs = append(se.ISRCList.ISRCs, struct {
+				ID string `xml:"id,attr"`
+			}{n})
+		}
+		se.ISRCList.Count = len(se.ISRCList.ISRCs)
+	}
+
+	return c.authRequest("POST", nil, body, url.Values{"client": {c.clientID}}, "/recording")
+}
+
 // SearchRecording queries MusicBrainz´ Search Server for Recordings.
 //
 // Possible search fields to provide in searchTerm are:
@@ -102,19 +203,14 @@ func (c *WS2Client) LookupRecording(id MBID, inc ...string) (*Recording, error)
 // http://musicbrainz.org/doc/Development/XML_Web_Service/Version_2/Search#Recording
 func (c *WS2Client) SearchRecording(searchTerm string, limit, offset int) (*RecordingSearchResponse, error) {
 
-	result := recordingListResult{}
-	err := c.searchRequest("/recording", &result, searchTerm, limit, offset)
-
-	rsp := RecordingSearchResponse{}
-	rsp.WS2ListResponse = result.RecordingList.WS2ListResponse
-	rsp.Scores = make(ScoreMap)
-
-	for i, v := range result.RecordingList.Recordings {
-		rsp.Recordings = append(rsp.Recordings, v.Recording)
-		rsp.Scores[rsp.Recordings[i]] = v.Score
-	}
+	result, err := Search[*Recording](context.Background(), c, searchTerm, limit, offset)
 
-	return &rsp, err
+	return &RecordingSearchResponse{
+		WS2ListResponse: result.WS2ListResponse,
+		Recordings:      Entities(result.Results),
+		Scores:          scoreMap(result.Results),
+		Results:         result.Results,
+	}, err
 }
 
 // RecordingSearchResponse is the response type returned by the SearchRecording
@@ -123,25 +219,10 @@ type RecordingSearchResponse struct {
 	WS2ListResponse
 	Recordings []*Recording
 	Scores     ScoreMap
+	Results    []Scored[*Recording]
 }
 
 // ResultsWithScore returns a slice of Recordings with a min score.
 func (r *RecordingSearchResponse) ResultsWithScore(score int) []*Recording {
-	var res []*Recording
-	for _, v := range r.Recordings {
-		if r.Scores[v] >= score {
-			res = append(res, v)
-		}
-	}
-	return res
-}
-
-type recordingListResult struct {
-	RecordingList struct {
-		WS2ListResponse
-		Recordings []struct {
-			*Recording
-			Score int `xml:"http://musicbrainz.org/ns/ext#-2.0 score,attr"`
-		} `xml:"recording"`
-	} `xml:"recording-list"`
+	return Entities(FilterByScore(r.Results, score))
 }
@@ -28,6 +28,7 @@ package gomusicbrainz
 import (
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestSearchRecording(t *testing.T) {
@@ -45,7 +46,7 @@ func TestSearchRecording(t *testing.T) {
 				ArtistCredit: ArtistCredit{
 					NameCredits: []NameCredit{
 						NameCredit{
-							Artist{
+							Artist: Artist{
 								ID:       "695e75b5-c6db-43ee-abeb-2f3e50d96c3e",
 								Name:     "Imperiet",
 								SortName: "Imperiet",
@@ -53,6 +54,52 @@ func TestSearchRecording(t *testing.T) {
 						},
 					},
 				},
+				Releases: []*Release{
+					{
+						ID:     "ae050d13-7f86-495e-9918-10d8c0ac58e8",
+						Title:  "Fred",
+						Status: ReleaseStatusOfficial,
+						ReleaseGroup: ReleaseGroup{
+							ID:          "d0e20525-9c3b-3f68-a130-bfca696526f2",
+							Type:        "Single",
+							PrimaryType: "Single",
+						},
+						Date: BrainzTime{
+							Time:     time.Date(1984, 12, 1, 0, 0, 0, 0, time.UTC),
+							Accuracy: Day,
+						},
+						CountryCode: "SE",
+						ReleaseEvents: []*ReleaseEvent{
+							{
+								Date: BrainzTime{
+									Time:     time.Date(1984, 12, 1, 0, 0, 0, 0, time.UTC),
+									Accuracy: Day,
+								},
+								Area: Area{
+									ID:            "23d10872-f5ae-3f0c-bf55-332788a16ecb",
+									Name:          "Sweden",
+									SortName:      "Sweden",
+									ISO31661Codes: []string{"SE"},
+								},
+							},
+						},
+						Mediums: []*Medium{
+							{
+								Format:   "7\" Vinyl",
+								Position: 1,
+								Tracks: []*Track{
+									{
+										ID:     "e111dc12-8ff7-399f-94c9-32fc493a7fc9",
+										Number: "A",
+										Title:  "Fred",
+										Length: 473000,
+									},
+								},
+								TrackCount: 2,
+							},
+						},
+					},
+				},
 				//TODO add missing fields
 			},
 		},
@@ -70,6 +117,7 @@ func TestSearchRecording(t *testing.T) {
 	want.Scores = ScoreMap{
 		returned.Recordings[0]: 100,
 	}
+	want.Results = []Scored[*Recording]{{Entity: returned.Recordings[0], Score: 100}}
 
 	if !reflect.DeepEqual(*returned, want) {
 		t.Error(requestDiff(&want, returned))
@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+)
+
+// Redirect describes a lookup of a merged entity. WS2 answers lookups of an
+// MBID which was merged into another entity with the surviving entity, so From
+// is the requested and To the canonical MBID.
+type Redirect struct {
+	Entity string // entity type, e.g. "artist"
+	From   MBID
+	To     MBID
+}
+
+// reportRedirect calls OnRedirect of c if entity, looked up with id, has a
+// different MBID.
+func (c *WS2Client) reportRedirect(entity MBLookupEntity, id MBID) {
+	if got := entity.Id(); got != "" && got != id && c.OnRedirect != nil {
+		c.OnRedirect(Redirect{Entity: elementName(entity), From: id, To: got})
+	}
+}
+
+// ResolveCanonicalMBIDs looks up the given MBIDs of entities of type T and
+// returns their canonical MBIDs, e.g. to clean up stored IDs of entities which
+// were merged since:
+//
+//	canonical, err := gomusicbrainz.ResolveCanonicalMBIDs[*gomusicbrainz.Artist](ctx, client, ids)
+//
+// The map holds an entry for each of ids. MBIDs of entities which are not
+// canonical anymore map to the MBID of the entity they were merged into, MBIDs
+// of deleted entities to "". Lookups run concurrently with BatchLookup. The
+// first error other than a 404 response is returned along with the MBIDs
+// resolved so far.
+func ResolveCanonicalMBIDs[T MBLookupEntity](ctx context.Context, c *WS2Client, ids []MBID) (map[MBID]MBID, error) {
+
+	items := make([]BatchItem, len(ids))
+	for i, id := range ids {
+		e := newEntity[T]()
+		reflect.ValueOf(e).Elem().FieldByName("ID").Set(reflect.ValueOf(id))
+		items[i] = BatchItem{Entity: e}
+	}
+
+	canonical := make(map[MBID]MBID, len(ids))
+	var firstErr error
+
+	for r := range c.BatchLookup(ctx, items, BatchOptions{}) {
+		id := ids[r.Index]
+
+		var ws2Err *WS2Error
+		switch {
+		case r.Err == nil:
+			canonical[id] = r.Entity.Id()
+		case errors.As(r.Err, &ws2Err) && ws2Err.StatusCode == http.StatusNotFound:
+			canonical[id] = ""
+		case firstErr == nil:
+			firstErr = r.Err
+		}
+	}
+
+	return canonical, firstErr
+}
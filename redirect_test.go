@@ -0,0 +1,122 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// serveMergedArtist serves LookupArtist.xml for its own MBID and for the MBID
+// of a merged artist. Other artists do not exist.
+func serveMergedArtist(t *testing.T) {
+	serveTestFile("/artist/10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8", "LookupArtist.xml", t)
+	serveTestFile("/artist/5b11f4ce-a62d-471e-81fc-a69a8278c7da", "LookupArtist.xml", t)
+	mux.HandleFunc("/artist/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Not Found", http.StatusNotFound)
+	})
+}
+
+func TestLookupRedirect(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+	serveMergedArtist(t)
+
+	var redirects []Redirect
+	client.OnRedirect = func(r Redirect) {
+		redirects = append(redirects, r)
+	}
+
+	if _, err := client.LookupArtist("10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8"); err != nil {
+		t.Fatal(err)
+	}
+	if len(redirects) != 0 {
+		t.Errorf("unexpected redirects %v", redirects)
+	}
+
+	artist, err := client.LookupArtist("5b11f4ce-a62d-471e-81fc-a69a8278c7da")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if artist.ID != "10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8" {
+		t.Errorf("got artist %s", artist.ID)
+	}
+
+	want := []Redirect{{
+		Entity: "artist",
+		From:   "5b11f4ce-a62d-471e-81fc-a69a8278c7da",
+		To:     "10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8",
+	}}
+	if !reflect.DeepEqual(redirects, want) {
+		t.Error(requestDiff(want, redirects))
+	}
+}
+
+func TestResolveCanonicalMBIDs(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+	serveMergedArtist(t)
+
+	var mu sync.Mutex
+	redirected := 0
+	client.OnRedirect = func(Redirect) {
+		mu.Lock()
+		redirected++
+		mu.Unlock()
+	}
+
+	ids := []MBID{
+		"10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8",
+		"5b11f4ce-a62d-471e-81fc-a69a8278c7da",
+		"c0b2500e-0cef-4130-869d-732b23ed9df5",
+	}
+
+	returned, err := ResolveCanonicalMBIDs[*Artist](context.Background(), client, ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[MBID]MBID{
+		"10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8": "10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8",
+		"5b11f4ce-a62d-471e-81fc-a69a8278c7da": "10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8",
+		"c0b2500e-0cef-4130-869d-732b23ed9df5": "",
+	}
+	if !reflect.DeepEqual(returned, want) {
+		t.Error(requestDiff(want, returned))
+	}
+	if redirected != 1 {
+		t.Errorf("got %d redirects, want 1", redirected)
+	}
+
+	if _, err := ResolveCanonicalMBIDs[*Artist](context.Background(), client, []MBID{"invalid"}); err == nil {
+		t.Error("expected error for invalid MBID")
+	}
+}
@@ -25,36 +25,143 @@
 
 package gomusicbrainz
 
-import "encoding/xml"
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"time"
+)
 
 // Release represents a unique release (i.e. issuing) of a product on a
 // specific date with specific release information such as the country, label,
 // barcode, packaging, etc. More information at https://musicbrainz.org/doc/Release
 type Release struct {
 	ID                 MBID               `xml:"id,attr"`
-	Title              string             `xml:"title"`
-	Status             string             `xml:"status"`
-	Disambiguation     string             `xml:"disambiguation"`
+	Title              string             `xml:"title,omitempty"`
+	Status             ReleaseStatus      `xml:"status,omitempty"`
+	StatusID           MBID               `xml:"-"`
+	Packaging          string             `xml:"packaging,omitempty"`
+	PackagingID        MBID               `xml:"-"`
+	Disambiguation     string             `xml:"disambiguation,omitempty"`
 	TextRepresentation TextRepresentation `xml:"text-representation"`
 	ArtistCredit       ArtistCredit       `xml:"artist-credit"`
 	ReleaseGroup       ReleaseGroup       `xml:"release-group"`
 	Date               BrainzTime         `xml:"date"`
-	CountryCode        string             `xml:"country"`
-	Barcode            string             `xml:"barcode"`
-	Asin               string             `xml:"asin"`
-	Quality            string             `xml:"quality"`
+	CountryCode        string             `xml:"country,omitempty"`
+	ReleaseEvents      []*ReleaseEvent    `xml:"release-event-list>release-event"`
+	Barcode            string             `xml:"barcode,omitempty"`
+	Asin               string             `xml:"asin,omitempty"`
+	Quality            string             `xml:"quality,omitempty"`
+	CoverArtArchive    CoverArtArchive    `xml:"cover-art-archive"`
 	LabelInfos         []LabelInfo        `xml:"label-info-list>label-info"`
 	Mediums            []*Medium          `xml:"medium-list>medium"`
 	Relations          TargetRelationsMap `xml:"relation-list"`
 }
 
-func (mbe *Release) lookupResult() interface{} {
+// UnmarshalXML is needed to implement XMLUnmarshaler for releases to decode
+// the type MBIDs of the status and packaging elements.
+func (mbe *Release) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+
+	type release Release
 	var res struct {
-		XMLName xml.Name `xml:"metadata"`
-		Ptr     *Release `xml:"release"`
+		*release
+		Status    idValue `xml:"status"`
+		Packaging idValue `xml:"packaging"`
+	}
+	res.release = (*release)(mbe)
+
+	if err := d.DecodeElement(&res, &start); err != nil {
+		return err
+	}
+
+	mbe.Status, mbe.StatusID = ParseReleaseStatus(res.Status.Value), res.Status.ID
+	mbe.Packaging, mbe.PackagingID = res.Packaging.Value, res.Packaging.ID
+
+	return nil
+}
+
+// MarshalXML is the counterpart of UnmarshalXML and encodes the type MBIDs of
+// the status and packaging elements. Empty releases and lists are omitted.
+func (mbe Release) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+
+	if isZero(mbe) {
+		return nil
+	}
+
+	type release Release
+	res := struct {
+		*release
+		Status        *idValue                `xml:"status"`
+		Packaging     *idValue                `xml:"packaging"`
+		ReleaseEvents *xmlList[*ReleaseEvent] `xml:"release-event-list"`
+		LabelInfos    *xmlList[LabelInfo]     `xml:"label-info-list"`
+		Mediums       *xmlList[*Medium]       `xml:"medium-list"`
+	}{
+		release:       (*release)(&mbe),
+		Status:        newIDValue(string(mbe.Status), mbe.StatusID),
+		Packaging:     newIDValue(mbe.Packaging, mbe.PackagingID),
+		ReleaseEvents: newXMLList("release-event", mbe.ReleaseEvents),
+		LabelInfos:    newXMLList("label-info", mbe.LabelInfos),
+		Mediums:       newXMLList("medium", mbe.Mediums),
+	}
+
+	return e.EncodeElement(res, start)
+}
+
+// TotalTracks returns the number of tracks of all mediums.
+func (mbe *Release) TotalTracks() int {
+
+	n := 0
+	for _, m := range mbe.Mediums {
+		if m.TrackCount > len(m.Tracks) {
+			n += m.TrackCount
+		} else {
+			n += len(m.Tracks)
+		}
+	}
+	return n
+}
+
+// Duration returns the sum of the lengths of all tracks including pregap and
+// data tracks. If the length of a track is unknown, the length of its
+// recording is used. The result is only complete if the release was looked up
+// with tracks included (inc=recordings).
+func (mbe *Release) Duration() time.Duration {
+
+	var ms int
+	add := func(t *Track) {
+		if t.Length > 0 {
+			ms += t.Length
+		} else {
+			ms += t.Recording.Length
+		}
+	}
+
+	for _, m := range mbe.Mediums {
+		if m.Pregap != nil {
+			add(m.Pregap)
+		}
+		for _, t := range m.Tracks {
+			add(t)
+		}
+		for _, t := range m.DataTracks {
+			add(t)
+		}
 	}
-	res.Ptr = mbe
-	return &res
+
+	return time.Duration(ms) * time.Millisecond
+}
+
+// ReleaseEvent is the date a release was issued in an area. A release can
+// have multiple release events e.g. for releases issued in several countries.
+type ReleaseEvent struct {
+	Date BrainzTime `xml:"date"`
+	Area Area       `xml:"area"`
+}
+
+func (mbe *Release) lookupResult() interface{} {
+	return &lookupDoc{mbe}
 }
 
 func (mbe *Release) apiEndpoint() string {
@@ -73,6 +180,34 @@ func (c *WS2Client) LookupRelease(id MBID, inc ...string) (*Release, error) {
 	return a, err
 }
 
+// BarcodeSubmission sets the barcode of a release.
+type BarcodeSubmission struct {
+	Release MBID
+	Barcode string
+}
+
+// SubmitBarcodes submits barcodes for the given releases. Barcodes must be
+// valid EAN-8, UPC-A, EAN-13 or GTIN-14 codes. This requires authentication,
+// see SetCredentials. For more information visit
+// https://musicbrainz.org/doc/Development/XML_Web_Service/Version_2#Barcode_submission
+func (c *WS2Client) SubmitBarcodes(barcodes ...BarcodeSubmission) error {
+
+	body := newSubmissionBody()
+
+	for _, s := range barcodes {
+		if !ValidBarcode(s.Barcode) {
+			return fmt.Errorf("invalid barcode %q for release %s", s.Barcode, s.Release)
+		}
+		se, err := body.entity(&Release{ID: s.Release})
+		if err != nil {
+			return err
+		}
+		se.Barcode = s.Barcode
+	}
+
+	return c.authRequest("POST", nil, body, url.Values{"client": {c.clientID}}, "/release")
+}
+
 // SearchRelease queries MusicBrainz´ Search Server for Releases.
 //
 // Possible search fields to provide in searchTerm are:
@@ -114,19 +249,14 @@ func (c *WS2Client) LookupRelease(id MBID, inc ...string) (*Release, error) {
 // https://musicbrainz.org/doc/Development/XML_Web_Service/Version_2/Search#Release
 func (c *WS2Client) SearchRelease(searchTerm string, limit, offset int) (*ReleaseSearchResponse, error) {
 
-	result := releaseListResult{}
-	err := c.searchRequest("/release", &result, searchTerm, limit, offset)
-
-	rsp := ReleaseSearchResponse{}
-	rsp.WS2ListResponse = result.ReleaseList.WS2ListResponse
-	rsp.Scores = make(ScoreMap)
+	result, err := Search[*Release](context.Background(), c, searchTerm, limit, offset)
 
-	for i, v := range result.ReleaseList.Releases {
-		rsp.Releases = append(rsp.Releases, v.Release)
-		rsp.Scores[rsp.Releases[i]] = v.Score
-	}
-
-	return &rsp, err
+	return &ReleaseSearchResponse{
+		WS2ListResponse: result.WS2ListResponse,
+		Releases:        Entities(result.Results),
+		Scores:          scoreMap(result.Results),
+		Results:         result.Results,
+	}, err
 }
 
 // ReleaseSearchResponse is the response type returned by the SearchRelease method.
@@ -134,17 +264,12 @@ type ReleaseSearchResponse struct {
 	WS2ListResponse
 	Releases []*Release
 	Scores   ScoreMap
+	Results  []Scored[*Release]
 }
 
 // ResultsWithScore returns a slice of Releases with a min score.
 func (r *ReleaseSearchResponse) ResultsWithScore(score int) []*Release {
-	var res []*Release
-	for _, v := range r.Releases {
-		if r.Scores[v] >= score {
-			res = append(res, v)
-		}
-	}
-	return res
+	return Entities(FilterByScore(r.Results, score))
 }
 
 // OriginalRelease is a helper function that returns the earliest release of
@@ -185,13 +310,3 @@ func OriginalRelease(releases []*Release) *Release {
 
 	return original
 }
-
-type releaseListResult struct {
-	ReleaseList struct {
-		WS2ListResponse
-		Releases []struct {
-			*Release
-			Score int `xml:"http://musicbrainz.org/ns/ext#-2.0 score,attr"`
-		} `xml:"release"`
-	} `xml:"release-list"`
-}
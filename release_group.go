@@ -25,29 +25,110 @@
 
 package gomusicbrainz
 
-import "encoding/xml"
+import (
+	"context"
+	"encoding/xml"
+	"strings"
+)
 
 // ReleaseGroup groups several different releases into a single logical entity.
 // Every release belongs to one, and only one release group. More informations
 // at https://musicbrainz.org/doc/Release_Group
+//
+// PrimaryType (e.g. "Album", "Single", "EP") and SecondaryTypes (e.g.
+// "Compilation", "Live", "Remix") classify a release group. Type is the
+// legacy type which combines both into one value, e.g. "Compilation" for an
+// album compilation, and should not be used to filter by primary type.
 type ReleaseGroup struct {
-	ID               MBID         `xml:"id,attr"`
-	Type             string       `xml:"type,attr"`
-	PrimaryType      string       `xml:"primary-type"`
-	Title            string       `xml:"title"`
-	FirstReleaseDate BrainzTime   `xml:"first-release-date"`
-	ArtistCredit     ArtistCredit `xml:"artist-credit"`
-	Releases         []*Release   `xml:"release-list>release"` // FIXME if important unmarshal count,attr
-	Tags             []*Tag       `xml:"tag-list>tag"`
+	ID               MBID             `xml:"id,attr"`
+	Type             string           `xml:"type,attr,omitempty"`
+	TypeID           MBID             `xml:"type-id,attr,omitempty"`
+	PrimaryType      ReleaseGroupType `xml:"primary-type,omitempty"`
+	PrimaryTypeID    MBID             `xml:"-"`
+	SecondaryTypes   []string         `xml:"secondary-type-list>secondary-type"`
+	SecondaryTypeIDs []MBID           `xml:"-"`
+	Title            string           `xml:"title,omitempty"`
+	FirstReleaseDate BrainzTime       `xml:"first-release-date"`
+	ArtistCredit     ArtistCredit     `xml:"artist-credit"`
+	Releases         []*Release       `xml:"release-list>release"` // FIXME if important unmarshal count,attr
+	Tags             []*Tag           `xml:"tag-list>tag"`
+	Rating           Rating           `xml:"rating"`
+	UserRating       int              `xml:"user-rating,omitempty"`
 }
 
-func (mbe *ReleaseGroup) lookupResult() interface{} {
+// UnmarshalXML is needed to implement XMLUnmarshaler for release groups to
+// decode the MBIDs of primary and secondary types.
+func (mbe *ReleaseGroup) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+
+	type releaseGroup ReleaseGroup
 	var res struct {
-		XMLName xml.Name      `xml:"metadata"`
-		Ptr     *ReleaseGroup `xml:"release-group"`
+		*releaseGroup
+		PrimaryType    idValue   `xml:"primary-type"`
+		SecondaryTypes []idValue `xml:"secondary-type-list>secondary-type"`
+	}
+	res.releaseGroup = (*releaseGroup)(mbe)
+
+	if err := d.DecodeElement(&res, &start); err != nil {
+		return err
+	}
+
+	mbe.PrimaryType, mbe.PrimaryTypeID = ParseReleaseGroupType(res.PrimaryType.Value), res.PrimaryType.ID
+	for _, v := range res.SecondaryTypes {
+		mbe.SecondaryTypes = append(mbe.SecondaryTypes, v.Value)
+		mbe.SecondaryTypeIDs = append(mbe.SecondaryTypeIDs, v.ID)
+	}
+
+	return nil
+}
+
+// MarshalXML is the counterpart of UnmarshalXML and encodes the MBIDs of
+// primary and secondary types. Empty release groups and lists are omitted.
+func (mbe ReleaseGroup) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+
+	if isZero(mbe) {
+		return nil
+	}
+
+	type releaseGroup ReleaseGroup
+	var secondaryTypes []idValue
+	for i, v := range mbe.SecondaryTypes {
+		t := idValue{Value: v}
+		if i < len(mbe.SecondaryTypeIDs) {
+			t.ID = mbe.SecondaryTypeIDs[i]
+		}
+		secondaryTypes = append(secondaryTypes, t)
+	}
+
+	res := struct {
+		*releaseGroup
+		PrimaryType    *idValue           `xml:"primary-type"`
+		SecondaryTypes *xmlList[idValue]  `xml:"secondary-type-list"`
+		Releases       *xmlList[*Release] `xml:"release-list"`
+		Tags           *xmlList[*Tag]     `xml:"tag-list"`
+	}{
+		releaseGroup:   (*releaseGroup)(&mbe),
+		PrimaryType:    newIDValue(string(mbe.PrimaryType), mbe.PrimaryTypeID),
+		SecondaryTypes: newXMLList("secondary-type", secondaryTypes),
+		Releases:       newXMLList("release", mbe.Releases),
+		Tags:           newXMLList("tag", mbe.Tags),
+	}
+
+	return e.EncodeElement(res, start)
+}
+
+// HasSecondaryType reports whether the release group has the given secondary
+// type e.g. "Compilation". The comparison is case-insensitive.
+func (mbe *ReleaseGroup) HasSecondaryType(t string) bool {
+	for _, v := range mbe.SecondaryTypes {
+		if strings.EqualFold(v, t) {
+			return true
+		}
 	}
-	res.Ptr = mbe
-	return &res
+	return false
+}
+
+func (mbe *ReleaseGroup) lookupResult() interface{} {
+	return &lookupDoc{mbe}
 }
 
 func (mbe *ReleaseGroup) apiEndpoint() string {
@@ -92,19 +173,14 @@ func (c *WS2Client) LookupReleaseGroup(id MBID, inc ...string) (*ReleaseGroup, e
 // https://musicbrainz.org/doc/Development/XML_Web_Service/Version_2/Search#Release_Group
 func (c *WS2Client) SearchReleaseGroup(searchTerm string, limit, offset int) (*ReleaseGroupSearchResponse, error) {
 
-	result := releaseGroupListResult{}
-	err := c.searchRequest("/release-group", &result, searchTerm, limit, offset)
-
-	rsp := ReleaseGroupSearchResponse{}
-	rsp.WS2ListResponse = result.ReleaseGroupList.WS2ListResponse
-	rsp.Scores = make(ScoreMap)
-
-	for i, v := range result.ReleaseGroupList.ReleaseGroups {
-		rsp.ReleaseGroups = append(rsp.ReleaseGroups, v.ReleaseGroup)
-		rsp.Scores[rsp.ReleaseGroups[i]] = v.Score
-	}
+	result, err := Search[*ReleaseGroup](context.Background(), c, searchTerm, limit, offset)
 
-	return &rsp, err
+	return &ReleaseGroupSearchResponse{
+		WS2ListResponse: result.WS2ListResponse,
+		ReleaseGroups:   Entities(result.Results),
+		Scores:          scoreMap(result.Results),
+		Results:         result.Results,
+	}, err
 }
 
 // ReleaseGroupSearchResponse is the response type returned by release group request
@@ -113,25 +189,10 @@ type ReleaseGroupSearchResponse struct {
 	WS2ListResponse
 	ReleaseGroups []*ReleaseGroup
 	Scores        ScoreMap
+	Results       []Scored[*ReleaseGroup]
 }
 
 // ResultsWithScore returns a slice of ReleaseGroups with a min score.
 func (r *ReleaseGroupSearchResponse) ResultsWithScore(score int) []*ReleaseGroup {
-	var res []*ReleaseGroup
-	for _, v := range r.ReleaseGroups {
-		if r.Scores[v] >= score {
-			res = append(res, v)
-		}
-	}
-	return res
-}
-
-type releaseGroupListResult struct {
-	ReleaseGroupList struct {
-		WS2ListResponse
-		ReleaseGroups []struct {
-			*ReleaseGroup
-			Score int `xml:"http://musicbrainz.org/ns/ext#-2.0 score,attr"`
-		} `xml:"release-group"`
-	} `xml:"release-group-list"`
+	return Entities(FilterByScore(r.Results, score))
 }
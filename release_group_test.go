@@ -28,6 +28,7 @@ package gomusicbrainz
 import (
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestSearchReleaseGroup(t *testing.T) {
@@ -46,7 +47,7 @@ func TestSearchReleaseGroup(t *testing.T) {
 				ArtistCredit: ArtistCredit{
 					NameCredits: []NameCredit{
 						NameCredit{
-							Artist{
+							Artist: Artist{
 								ID:             "a8fa58d8-f60b-4b83-be7c-aea1af11596b",
 								Name:           "Fred Giannelli",
 								SortName:       "Giannelli, Fred",
@@ -87,8 +88,44 @@ func TestSearchReleaseGroup(t *testing.T) {
 	want.Scores = ScoreMap{
 		returned.ReleaseGroups[0]: 100,
 	}
+	want.Results = []Scored[*ReleaseGroup]{{Entity: returned.ReleaseGroups[0], Score: 100}}
 
 	if !reflect.DeepEqual(*returned, want) {
 		t.Error(requestDiff(&want, returned))
 	}
 }
+
+func TestLookupReleaseGroup(t *testing.T) {
+
+	want := ReleaseGroup{
+		ID:               "1dc4c347-a1db-32aa-b14f-bc9cc507b843",
+		Type:             "Compilation",
+		TypeID:           "dd2a21e1-0c00-3729-a7a0-de60b84eb5d1",
+		Title:            "Collected",
+		PrimaryType:      "Album",
+		PrimaryTypeID:    "f529b476-6e62-324f-b0aa-1f3e33d313fc",
+		SecondaryTypes:   []string{"Compilation"},
+		SecondaryTypeIDs: []MBID{"dd2a21e1-0c00-3729-a7a0-de60b84eb5d1"},
+		FirstReleaseDate: BrainzTime{
+			Time:     time.Date(2006, 3, 27, 0, 0, 0, 0, time.UTC),
+			Accuracy: Day,
+		},
+	}
+
+	setupHTTPTesting()
+	defer server.Close()
+	serveTestFile("/release-group/1dc4c347-a1db-32aa-b14f-bc9cc507b843", "LookupReleaseGroup.xml", t)
+
+	returned, err := client.LookupReleaseGroup("1dc4c347-a1db-32aa-b14f-bc9cc507b843")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(*returned, want) {
+		t.Error(requestDiff(&want, returned))
+	}
+
+	if !returned.HasSecondaryType("compilation") || returned.HasSecondaryType("Live") {
+		t.Error("unexpected HasSecondaryType result")
+	}
+}
@@ -0,0 +1,187 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"sort"
+	"strings"
+)
+
+// ReleaseSelector chooses the preferred release among several, e.g. the
+// release of a release group to display. Releases are compared by the
+// following criteria in this order, the first difference decides:
+//
+//	Statuses   preferred statuses, e.g. Official before Promotion
+//	Countries  preferred countries of the release or any of its release events
+//	Formats    preferred formats of the first medium
+//	Languages  preferred languages of the text representation
+//	Scripts    preferred scripts of the text representation
+//	quality    high data quality before normal and low
+//	date       earliest (or latest with Latest) date, releases without date last
+//	MBID       lowest MBID to break ties deterministically
+//
+// Values missing from a preference list rank behind all listed ones. Nil lists
+// are skipped, so the zero ReleaseSelector prefers high data quality and the
+// earliest release.
+type ReleaseSelector struct {
+	Statuses  []ReleaseStatus
+	Countries []string // ISO 3166-1 codes e.g. "GB", "XW" for worldwide
+
+	// Formats, e.g. "CD", "Digital Media" or "Vinyl", also match formats
+	// ending with them, so "Vinyl" matches `12" Vinyl` and "CD" matches
+	// "Enhanced CD", but not "CD-R".
+	Formats []string
+
+	Languages []string // ISO 639-3 codes e.g. "eng"
+	Scripts   []string // ISO 15924 codes e.g. "Latn"
+
+	Latest bool // prefer the latest instead of the earliest release
+}
+
+// Select returns the preferred release or nil if there are no releases. s may
+// be nil.
+func (s *ReleaseSelector) Select(releases []*Release) *Release {
+
+	var best *Release
+	for _, r := range releases {
+		if best == nil || s.Less(r, best) {
+			best = r
+		}
+	}
+	return best
+}
+
+// Rank returns a copy of releases sorted from the most to the least preferred
+// release. s may be nil.
+func (s *ReleaseSelector) Rank(releases []*Release) []*Release {
+
+	res := make([]*Release, len(releases))
+	copy(res, releases)
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return s.Less(res[i], res[j])
+	})
+	return res
+}
+
+// Less reports whether release a is preferred to b.
+func (s *ReleaseSelector) Less(a, b *Release) bool {
+
+	if s == nil {
+		s = &ReleaseSelector{}
+	}
+
+	ranks := [][2]int{
+		{statusRank(s.Statuses, a.Status), statusRank(s.Statuses, b.Status)},
+		{countryRank(s.Countries, a), countryRank(s.Countries, b)},
+		{formatRank(s.Formats, a), formatRank(s.Formats, b)},
+		{preferenceRank(s.Languages, a.TextRepresentation.Language), preferenceRank(s.Languages, b.TextRepresentation.Language)},
+		{preferenceRank(s.Scripts, a.TextRepresentation.Script), preferenceRank(s.Scripts, b.TextRepresentation.Script)},
+		{qualityRank(a.Quality), qualityRank(b.Quality)},
+	}
+	for _, r := range ranks {
+		if r[0] != r[1] {
+			return r[0] < r[1]
+		}
+	}
+
+	if a.Date.IsZero() != b.Date.IsZero() {
+		return b.Date.IsZero()
+	}
+	if !a.Date.Time.Equal(b.Date.Time) {
+		return a.Date.Time.Before(b.Date.Time) != s.Latest
+	}
+	return a.ID < b.ID
+}
+
+// PreferredRelease returns the release of the release group preferred by s,
+// which may be nil. The releases have to be included in the lookup, e.g.
+// with inc "releases" and "media" to rank by format.
+func (mbe *ReleaseGroup) PreferredRelease(s *ReleaseSelector) *Release {
+	return s.Select(mbe.Releases)
+}
+
+// preferenceRank returns the index of v in prefs or len(prefs) if v is not
+// preferred. Values are compared case-insensitively.
+func preferenceRank(prefs []string, v string) int {
+	for i, p := range prefs {
+		if strings.EqualFold(p, v) {
+			return i
+		}
+	}
+	return len(prefs)
+}
+
+func statusRank(prefs []ReleaseStatus, s ReleaseStatus) int {
+	for i, p := range prefs {
+		if strings.EqualFold(string(p), string(s)) {
+			return i
+		}
+	}
+	return len(prefs)
+}
+
+// countryRank returns the best rank of the country of r and the countries of
+// its release events.
+func countryRank(prefs []string, r *Release) int {
+
+	rank := preferenceRank(prefs, r.CountryCode)
+	for _, e := range r.ReleaseEvents {
+		for _, code := range e.Area.ISO31661Codes {
+			rank = min(rank, preferenceRank(prefs, code))
+		}
+	}
+	return rank
+}
+
+// formatRank returns the rank of the format of the first medium of r.
+func formatRank(prefs []string, r *Release) int {
+
+	if len(r.Mediums) == 0 {
+		return len(prefs)
+	}
+	format := strings.ToLower(r.Mediums[0].Format)
+
+	for i, p := range prefs {
+		p = strings.ToLower(p)
+		if format == p || strings.HasSuffix(format, " "+p) {
+			return i
+		}
+	}
+	return len(prefs)
+}
+
+// qualityRank ranks the data quality of a release, unknown quality counts as
+// normal.
+func qualityRank(q string) int {
+	switch strings.ToLower(q) {
+	case "high":
+		return 0
+	case "low":
+		return 2
+	}
+	return 1
+}
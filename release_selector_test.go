@@ -0,0 +1,155 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// selectorTestReleases returns releases of one album which differ in status,
+// country, format, text representation, quality and date.
+func selectorTestReleases(t *testing.T) []*Release {
+
+	release := func(id MBID, status ReleaseStatus, country, format, lang, script, quality, date string) *Release {
+		return &Release{
+			ID:                 id,
+			Status:             status,
+			CountryCode:        country,
+			Mediums:            []*Medium{{Format: format}},
+			TextRepresentation: TextRepresentation{Language: lang, Script: script},
+			Quality:            quality,
+			Date:               mustParseBrainzTime(t, date),
+		}
+	}
+
+	jp := release("0b1c2d3e-4f5a-4b6c-8d7e-8f9a0b1c2d3e", ReleaseStatusOfficial, "JP", "SHM-CD", "jpn", "Jpan", "normal", "2008-06-25")
+	jp.ReleaseEvents = []*ReleaseEvent{{Area: Area{ISO31661Codes: []string{"JP"}}}}
+
+	return []*Release{
+		release("5a6b7c8d-9e0f-4a1b-8c2d-3e4f5a6b7c8d", ReleaseStatusOfficial, "GB", "CD", "eng", "Latn", "normal", "2003-05-12"),
+		release("1c2d3e4f-5a6b-4c7d-8e9f-0a1b2c3d4e5f", ReleaseStatusOfficial, "US", "CD", "eng", "Latn", "normal", "2004-02-02"),
+		release("9d8c7b6a-5f4e-4d3c-ab1a-0f9e8d7c6b5a", ReleaseStatusOfficial, "XW", "Digital Media", "eng", "Latn", "high", "2010-01-01"),
+		release("3e4f5a6b-7c8d-4e9f-a0b1-c2d3e4f5a6b7", ReleaseStatusOfficial, "GB", `12" Vinyl`, "eng", "Latn", "normal", "2003-05-12"),
+		release("7f8a9b0c-1d2e-4f3a-9b4c-5d6e7f8a9b0c", ReleaseStatusPromotion, "GB", "CD-R", "eng", "Latn", "low", "2003-04-01"),
+		release("2b3c4d5e-6f7a-4b8c-9d0e-1f2a3b4c5d6e", ReleaseStatusOfficial, "DE", "CD", "deu", "Latn", "normal", ""),
+		jp,
+	}
+}
+
+func TestReleaseSelectorSelect(t *testing.T) {
+
+	releases := selectorTestReleases(t)
+
+	tests := []struct {
+		name     string
+		selector *ReleaseSelector
+		want     MBID
+	}{
+		{"nil prefers high quality", nil, "9d8c7b6a-5f4e-4d3c-ab1a-0f9e8d7c6b5a"},
+		{"status", &ReleaseSelector{Statuses: []ReleaseStatus{ReleaseStatusPromotion}}, "7f8a9b0c-1d2e-4f3a-9b4c-5d6e7f8a9b0c"},
+		// the CD and the vinyl are released on the same day
+		{"country, format suffix", &ReleaseSelector{Countries: []string{"GB"}, Formats: []string{"vinyl"}}, "3e4f5a6b-7c8d-4e9f-a0b1-c2d3e4f5a6b7"},
+		{"country before format", &ReleaseSelector{Countries: []string{"US"}, Formats: []string{"Vinyl"}}, "1c2d3e4f-5a6b-4c7d-8e9f-0a1b2c3d4e5f"},
+		{"release event country", &ReleaseSelector{Countries: []string{"JP"}}, "0b1c2d3e-4f5a-4b6c-8d7e-8f9a0b1c2d3e"},
+		{"language", &ReleaseSelector{Languages: []string{"deu"}}, "2b3c4d5e-6f7a-4b8c-9d0e-1f2a3b4c5d6e"},
+		{"script", &ReleaseSelector{Scripts: []string{"Jpan"}}, "0b1c2d3e-4f5a-4b6c-8d7e-8f9a0b1c2d3e"},
+		{"earliest", &ReleaseSelector{Countries: []string{"GB"}, Formats: []string{"CD"}}, "5a6b7c8d-9e0f-4a1b-8c2d-3e4f5a6b7c8d"},
+		{"latest", &ReleaseSelector{Formats: []string{"CD"}, Latest: true}, "1c2d3e4f-5a6b-4c7d-8e9f-0a1b2c3d4e5f"},
+	}
+
+	for _, test := range tests {
+		if got := test.selector.Select(releases); got.ID != test.want {
+			t.Errorf("%s: got %s, want %s", test.name, got.ID, test.want)
+		}
+	}
+
+	for format, want := range map[string]int{"CD": 0, "Enhanced CD": 0, "CD-R": 1, "SHM-CD": 1} {
+		if got := formatRank([]string{"cd"}, &Release{Mediums: []*Medium{{Format: format}}}); got != want {
+			t.Errorf("format %q has rank %d, want %d", format, got, want)
+		}
+	}
+
+	if got := (&ReleaseSelector{}).Select(nil); got != nil {
+		t.Errorf("got %v for no releases", got)
+	}
+}
+
+func TestReleaseSelectorRank(t *testing.T) {
+
+	releases := selectorTestReleases(t)
+	s := &ReleaseSelector{
+		Statuses:  []ReleaseStatus{ReleaseStatusOfficial},
+		Countries: []string{"GB", "US"},
+		Formats:   []string{"CD", "Vinyl"},
+	}
+
+	want := []MBID{
+		"5a6b7c8d-9e0f-4a1b-8c2d-3e4f5a6b7c8d", // GB CD
+		"3e4f5a6b-7c8d-4e9f-a0b1-c2d3e4f5a6b7", // GB Vinyl
+		"1c2d3e4f-5a6b-4c7d-8e9f-0a1b2c3d4e5f", // US CD
+		"2b3c4d5e-6f7a-4b8c-9d0e-1f2a3b4c5d6e", // CD
+		"9d8c7b6a-5f4e-4d3c-ab1a-0f9e8d7c6b5a", // high quality
+		"0b1c2d3e-4f5a-4b6c-8d7e-8f9a0b1c2d3e",
+		"7f8a9b0c-1d2e-4f3a-9b4c-5d6e7f8a9b0c", // promotion
+	}
+
+	// the ranking does not depend on the order of the input
+	for i := 0; i < len(releases); i++ {
+		rotated := append(append([]*Release{}, releases[i:]...), releases[:i]...)
+
+		var returned []MBID
+		for _, r := range s.Rank(rotated) {
+			returned = append(returned, r.ID)
+		}
+		if !reflect.DeepEqual(returned, want) {
+			t.Errorf("rotation %d: got %v, want %v", i, returned, want)
+		}
+	}
+}
+
+func TestReleaseSelectorTieBreak(t *testing.T) {
+
+	date := BrainzTime{Time: time.Date(2003, 5, 12, 0, 0, 0, 0, time.UTC), Accuracy: Day}
+	a := &Release{ID: "1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d", Date: date}
+	b := &Release{ID: "e1f2a3b4-c5d6-4e7f-8a9b-0c1d2e3f4a5b", Date: date}
+
+	var s *ReleaseSelector
+	if !s.Less(a, b) || s.Less(b, a) {
+		t.Error("identical releases are not ordered by MBID")
+	}
+}
+
+func TestReleaseGroupPreferredRelease(t *testing.T) {
+
+	rg := &ReleaseGroup{Releases: selectorTestReleases(t)}
+
+	got := rg.PreferredRelease(&ReleaseSelector{Countries: []string{"XW"}})
+	if got == nil || got.ID != "9d8c7b6a-5f4e-4d3c-ab1a-0f9e8d7c6b5a" {
+		t.Errorf("got %v", got)
+	}
+}
@@ -42,7 +42,7 @@ func TestSearchRelease(t *testing.T) {
 			{
 				ID:     "9ab1b03e-6722-4ab8-bc7f-a8722f0d34c1",
 				Title:  "Fred Schneider & The Shake Society",
-				Status: "official",
+				Status: ReleaseStatusOfficial,
 				TextRepresentation: TextRepresentation{
 					Language: "eng",
 					Script:   "latn",
@@ -50,7 +50,7 @@ func TestSearchRelease(t *testing.T) {
 				ArtistCredit: ArtistCredit{
 					NameCredits: []NameCredit{
 						NameCredit{
-							Artist{
+							Artist: Artist{
 								ID:       "43bcca8b-9edc-4997-8343-122350e790bf",
 								Name:     "Fred Schneider",
 								SortName: "Schneider, Fred",
@@ -78,7 +78,9 @@ func TestSearchRelease(t *testing.T) {
 				},
 				Mediums: []*Medium{
 					{
-						Format: "cd",
+						Format:     "cd",
+						DiscCount:  2,
+						TrackCount: 9,
 					},
 				},
 			},
@@ -97,10 +99,206 @@ func TestSearchRelease(t *testing.T) {
 	want.Scores = ScoreMap{
 		returned.Releases[0]: 100,
 	}
+	want.Results = []Scored[*Release]{{Entity: returned.Releases[0], Score: 100}}
 
 	if !reflect.DeepEqual(*returned, want) {
 		t.Error(requestDiff(&want, returned))
 	}
 }
 
-//TODO implement Lookup test with mediums and tracks
+func TestLookupRelease(t *testing.T) {
+
+	want := Release{
+		ID:          "07832b54-8266-47d5-bb0e-62c7f2cf5da5",
+		Title:       "Protection",
+		Status:      "Official",
+		StatusID:    "4e304316-386d-3409-af2e-78857eec5cfe",
+		Packaging:   "Jewel Case",
+		PackagingID: "ec27701a-4a22-37f4-bfac-6616e0f9750a",
+		TextRepresentation: TextRepresentation{
+			Language: "eng",
+			Script:   "Latn",
+		},
+		ReleaseGroup: ReleaseGroup{
+			ID:            "4e8dd3f3-f6f3-3d4b-a9c4-e0b5a4eb5f35",
+			Type:          "Album",
+			TypeID:        "f529b476-6e62-324f-b0aa-1f3e33d313fc",
+			Title:         "Protection",
+			PrimaryType:   "Album",
+			PrimaryTypeID: "f529b476-6e62-324f-b0aa-1f3e33d313fc",
+			FirstReleaseDate: BrainzTime{
+				Time:     time.Date(1994, 9, 26, 0, 0, 0, 0, time.UTC),
+				Accuracy: Day,
+			},
+		},
+		Date: BrainzTime{
+			Time:     time.Date(1995, 1, 24, 0, 0, 0, 0, time.UTC),
+			Accuracy: Day,
+		},
+		CountryCode: "US",
+		ReleaseEvents: []*ReleaseEvent{
+			{
+				Date: BrainzTime{
+					Time:     time.Date(1995, 1, 24, 0, 0, 0, 0, time.UTC),
+					Accuracy: Day,
+				},
+				Area: Area{
+					ID:            "489ce91b-6658-3307-9877-795b68554c98",
+					Name:          "United States",
+					SortName:      "United States",
+					ISO31661Codes: []string{"US"},
+				},
+			},
+			{
+				Date: BrainzTime{
+					Time:     time.Date(1995, 2, 1, 0, 0, 0, 0, time.UTC),
+					Accuracy: Month,
+				},
+				Area: Area{
+					ID:            "85752fda-13c4-31a3-bee5-0e5cb1f51dad",
+					Name:          "Germany",
+					SortName:      "Germany",
+					ISO31661Codes: []string{"DE"},
+				},
+			},
+		},
+		Barcode: "724383988327",
+		Asin:    "B000002N0B",
+		Quality: "normal",
+		CoverArtArchive: CoverArtArchive{
+			Artwork: true,
+			Count:   2,
+			Front:   true,
+			Back:    true,
+		},
+	}
+
+	setupHTTPTesting()
+	defer server.Close()
+	serveTestFile("/release/07832b54-8266-47d5-bb0e-62c7f2cf5da5", "LookupRelease.xml", t)
+
+	returned, err := client.LookupRelease("07832b54-8266-47d5-bb0e-62c7f2cf5da5")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(*returned, want) {
+		t.Error(requestDiff(&want, returned))
+	}
+}
+
+func TestLookupReleaseMediums(t *testing.T) {
+
+	gophers := ArtistCredit{
+		NameCredits: []NameCredit{
+			{
+				Artist: Artist{
+					ID:       "0e9c4b3f-3a7e-4e3b-9a4b-5f1d2c3b4a52",
+					Name:     "The Gophers",
+					SortName: "Gophers, The",
+				},
+			},
+		},
+	}
+
+	want := Release{
+		ID:    "b3b9f9a4-7a43-4e1b-9cb0-c5a2f2fbd1c8",
+		Title: "Gopher Sessions",
+		Mediums: []*Medium{
+			{
+				Title:    "Live at the Burrow",
+				Format:   "CD",
+				FormatID: "9712d52a-4509-3d4b-a1a2-67c88c643e31",
+				Position: 1,
+				Discs: []*Disc{
+					{
+						ID:      "lwHl8fGzJyLXQR33ug60E8jhf4k-",
+						Sectors: 61290,
+						Offsets: []int{150, 30255},
+					},
+				},
+				DiscCount: 1,
+				Pregap: &Track{
+					ID:     "0f1b6c3e-5b5c-4e7e-a1a6-9c4b6b2c8d10",
+					Number: "0",
+					Title:  "Hidden Intro",
+					Length: 12000,
+					Recording: Recording{
+						ID:     "6d6c1d0e-9a2b-4c8f-8b61-2a7f3f0f1e20",
+						Title:  "Hidden Intro",
+						Length: 12000,
+					},
+				},
+				Tracks: []*Track{
+					{
+						ID:           "1b1c0d72-2f5b-4a5c-a8a5-0d9e2b6a7c31",
+						Position:     1,
+						Number:       "A1",
+						Title:        "Dig (Live)",
+						Length:       401000,
+						ArtistCredit: gophers,
+						Recording: Recording{
+							ID:     "2b2c1e83-3f6c-4b6d-b9b6-1e0f3c7b8d42",
+							Title:  "Dig",
+							Length: 400500,
+						},
+					},
+					{
+						ID:       "3c3d2f94-4a7d-4c7e-8ac7-2f1a4d8c9e53",
+						Position: 2,
+						Number:   "A2",
+						Title:    "Burrow",
+						Recording: Recording{
+							ID:     "4d4e3a05-5b8e-4d8f-9bd8-3a2b5e9d0f64",
+							Title:  "Burrow",
+							Length: 299000,
+						},
+					},
+				},
+				TrackCount: 2,
+				DataTracks: []*Track{
+					{
+						ID:       "5e5f4b16-6c9f-4e9a-8ce9-4b3c6f0e1a75",
+						Position: 3,
+						Number:   "3",
+						Title:    "Video",
+						Length:   60000,
+						Recording: Recording{
+							ID:    "6f6a5c27-7d0a-4f0b-9dfa-5c4d7a1f2b86",
+							Title: "Video",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	setupHTTPTesting()
+	defer server.Close()
+	serveTestFile("/release/b3b9f9a4-7a43-4e1b-9cb0-c5a2f2fbd1c8", "LookupReleaseMediums.xml", t)
+
+	returned, err := client.LookupRelease("b3b9f9a4-7a43-4e1b-9cb0-c5a2f2fbd1c8",
+		"recordings", "discids", "artist-credits")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(*returned, want) {
+		t.Error(requestDiff(&want, returned))
+	}
+
+	if n := returned.TotalTracks(); n != 2 {
+		t.Errorf("TotalTracks() = %d, want 2", n)
+	}
+
+	// 12000 + 401000 + 299000 (recording length) + 60000
+	if d := returned.Duration(); d != 772*time.Second {
+		t.Errorf("Duration() = %s, want 12m52s", d)
+	}
+
+	m := returned.Mediums[0]
+	if m.TrackByNumber("A2") != m.Tracks[1] || m.TrackByNumber("0") != m.Pregap ||
+		m.TrackByNumber("3") != m.DataTracks[0] || m.TrackByNumber("B1") != nil {
+		t.Error("unexpected TrackByNumber result")
+	}
+}
@@ -44,8 +44,8 @@ func main() {
 	resp, _ := client.SearchArtist(`artist:"Parov Stelar"`, -1, -1)
 
 	// Pretty print Name and score of each returned artist.
-	for _, artist := range resp.Artists {
-		fmt.Printf("Name: %-25sScore: %d\n", artist.Name, resp.Scores[artist])
+	for _, r := range resp.Results {
+		fmt.Printf("Name: %-25sScore: %d\n", r.Entity.Name, r.Score)
 	}
 
 }
@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import "sort"
+
+// Scored is a search result, i.e. an entity and the score (0-100) MusicBrainz'
+// search server assigned to it. The Results field of every search response
+// contains the results in the order returned by WS2.
+type Scored[T any] struct {
+	Entity T
+	Score  int
+}
+
+// FilterByScore returns the results with a score of at least min.
+func FilterByScore[T any](results []Scored[T], min int) []Scored[T] {
+	var res []Scored[T]
+	for _, r := range results {
+		if r.Score >= min {
+			res = append(res, r)
+		}
+	}
+	return res
+}
+
+// SortByScore sorts results by descending score. The order of results with
+// equal scores is kept.
+func SortByScore[T any](results []Scored[T]) {
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+}
+
+// BestMatch returns the result with the highest score. If several results
+// share the highest score, the first one is returned. ok is false if results
+// is empty.
+func BestMatch[T any](results []Scored[T]) (best Scored[T], ok bool) {
+	for i, r := range results {
+		if i == 0 || r.Score > best.Score {
+			best = r
+		}
+	}
+	return best, len(results) > 0
+}
+
+// Entities returns the entities of results.
+func Entities[T any](results []Scored[T]) []T {
+	var res []T
+	for _, r := range results {
+		res = append(res, r.Entity)
+	}
+	return res
+}
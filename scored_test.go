@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScoredHelpers(t *testing.T) {
+
+	a, b, c := &Artist{Name: "a"}, &Artist{Name: "b"}, &Artist{Name: "c"}
+	results := []Scored[*Artist]{{a, 80}, {b, 100}, {c, 80}}
+
+	if got := FilterByScore(results, 90); !reflect.DeepEqual(got, []Scored[*Artist]{{b, 100}}) {
+		t.Errorf("FilterByScore = %v", got)
+	}
+
+	best, ok := BestMatch(results)
+	if !ok || best.Entity != b {
+		t.Errorf("BestMatch = %v, %v", best, ok)
+	}
+	if _, ok := BestMatch([]Scored[*Artist]{}); ok {
+		t.Error("BestMatch of empty results must not be ok")
+	}
+
+	SortByScore(results)
+	if got := Entities(results); !reflect.DeepEqual(got, []*Artist{b, a, c}) {
+		t.Errorf("SortByScore: got %v", got)
+	}
+}
+
+func TestResultsWithScoreAfterCopy(t *testing.T) {
+
+	rsp := ArtistSearchResponse{
+		Results: []Scored[*Artist]{
+			{&Artist{Name: "Gopher"}, 100},
+			{&Artist{Name: "Gophers"}, 40},
+		},
+	}
+
+	// scores are kept with the entity, so copying the results is safe.
+	results := append([]Scored[*Artist](nil), rsp.Results...)
+	results[0].Entity = &Artist{Name: "Gopher"}
+	rsp.Results = results
+
+	if got := rsp.ResultsWithScore(50); len(got) != 1 || got[0].Name != "Gopher" {
+		t.Errorf("ResultsWithScore(50) = %v", got)
+	}
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"context"
+	"encoding/xml"
+)
+
+// Series represents a sequence of separate release groups, releases,
+// recordings, works or events with a common theme, e.g. a compilation series
+// or a festival held every year. The order of the entities is given by the
+// ordering keys of the series' relations. See
+// https://musicbrainz.org/doc/Series
+type Series struct {
+	ID             MBID               `xml:"id,attr"`
+	Type           string             `xml:"type,attr,omitempty"`
+	TypeID         MBID               `xml:"type-id,attr,omitempty"`
+	Name           string             `xml:"name,omitempty"`
+	Disambiguation string             `xml:"disambiguation,omitempty"`
+	Aliases        []*Alias           `xml:"alias-list>alias"`
+	Tags           []*Tag             `xml:"tag-list>tag"`
+	Relations      TargetRelationsMap `xml:"relation-list"`
+}
+
+// MarshalXML omits the alias-list and tag-list if they are empty.
+func (mbe Series) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+
+	type series Series
+	res := struct {
+		*series
+		Aliases *xmlList[*Alias] `xml:"alias-list"`
+		Tags    *xmlList[*Tag]   `xml:"tag-list"`
+	}{
+		series:  (*series)(&mbe),
+		Aliases: newXMLList("alias", mbe.Aliases),
+		Tags:    newXMLList("tag", mbe.Tags),
+	}
+
+	return e.EncodeElement(res, start)
+}
+
+func (mbe *Series) lookupResult() interface{} {
+	return &lookupDoc{mbe}
+}
+
+func (mbe *Series) apiEndpoint() string {
+	return "/series"
+}
+
+func (mbe *Series) Id() MBID {
+	return mbe.ID
+}
+
+// LookupSeries performs a series lookup request for the given MBID.
+func (c *WS2Client) LookupSeries(id MBID, inc ...string) (*Series, error) {
+	return Lookup[*Series](context.Background(), c, id, inc...)
+}
+
+// SearchSeries queries MusicBrainz´ Search Server for Series.
+//
+// Possible search fields to provide in searchTerm are:
+//
+//	alias    the aliases/misspellings for this series
+//	comment  disambiguation comment
+//	series   name of the series
+//	sid      MBID of the series
+//	tag      folksonomy tag
+//	type     series type
+//
+// With no fields specified searchTerm searches the series and alias fields.
+// For more information visit
+// https://musicbrainz.org/doc/Development/XML_Web_Service/Version_2/Search#Series
+func (c *WS2Client) SearchSeries(searchTerm string, limit, offset int) (*SearchResponse[*Series], error) {
+	return Search[*Series](context.Background(), c, searchTerm, limit, offset)
+}
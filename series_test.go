@@ -0,0 +1,72 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLookupSeries(t *testing.T) {
+
+	want := Series{
+		ID:             "d977f7fd-96c9-4e3e-83b5-eb484a9e6582",
+		Type:           "Release group series",
+		TypeID:         "4c1c4949-7b6c-3a2d-9d54-a50a27e4fa77",
+		Name:           "Bravo Hits",
+		Disambiguation: "German compilation series",
+		Relations: TargetRelationsMap{
+			"release": []Relation{
+				&ReleaseRelation{
+					RelationAbstract: RelationAbstract{
+						Type:        "part of",
+						TypeID:      "3fa29f01-8e13-3e49-9b0a-ad212aa2f81d",
+						Target:      "8a1c9f4e-0b6a-4c26-9f5e-6f3b3e6b1d2a",
+						OrderingKey: 1,
+						Direction:   "backward",
+					},
+					Release: Release{
+						ID:    "8a1c9f4e-0b6a-4c26-9f5e-6f3b3e6b1d2a",
+						Title: "Bravo Hits 1",
+					},
+				},
+			},
+		},
+	}
+
+	setupHTTPTesting()
+	defer server.Close()
+	serveTestFile("/series/d977f7fd-96c9-4e3e-83b5-eb484a9e6582", "LookupSeries.xml", t)
+
+	returned, err := client.LookupSeries("d977f7fd-96c9-4e3e-83b5-eb484a9e6582")
+	if err != nil {
+		t.Error(err)
+	}
+
+	if !reflect.DeepEqual(*returned, want) {
+		t.Error(requestDiff(&want, returned))
+	}
+}
@@ -0,0 +1,221 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+)
+
+// StrictMode selects how a WS2Client handles elements and attributes of a
+// response that are not decoded into the result, e.g. because MusicBrainz
+// added a field gomusicbrainz does not know yet.
+type StrictMode int
+
+const (
+	// StrictOff silently drops unmapped elements and attributes. This is the
+	// default.
+	StrictOff StrictMode = iota
+
+	// StrictWarn collects unmapped elements and attributes as warnings which
+	// can be retrieved with WS2Client.Warnings. Only the last MaxWarnings
+	// warnings are kept.
+	StrictWarn
+
+	// StrictError makes requests return an *UnmappedError. The result is
+	// decoded nevertheless.
+	StrictError
+)
+
+// MaxWarnings is the number of warnings a WS2Client keeps in StrictWarn mode.
+// Older warnings are dropped if Warnings is not called.
+const MaxWarnings = 100
+
+// UnmappedError lists the elements and attributes of a response which were
+// not decoded into the result. Paths are relative to the metadata element
+// e.g. "artist/ipi-list/ipi" for an element or "artist/@type" for an
+// attribute.
+type UnmappedError struct {
+	Endpoint string
+	Paths    []string
+}
+
+func (e *UnmappedError) Error() string {
+	return fmt.Sprintf("%s: unmapped MMD data: %s", e.Endpoint, strings.Join(e.Paths, ", "))
+}
+
+// Warnings returns the unmapped data collected in StrictWarn mode since the
+// last call of Warnings, at most the last MaxWarnings.
+func (c *WS2Client) Warnings() []*UnmappedError {
+	c.warningsMu.Lock()
+	defer c.warningsMu.Unlock()
+
+	w := c.warnings
+	c.warnings = nil
+	return w
+}
+
+// decode decodes the MMD document read from r into result and handles
+// unmapped data according to c.Strict.
+func (c *WS2Client) decode(r io.Reader, result interface{}, endpoint string) error {
+
+	if c.Strict == StrictOff {
+		return xml.NewDecoder(r).Decode(result)
+	}
+
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if err := xml.Unmarshal(data, result); err != nil {
+		return err
+	}
+
+	paths, err := unmappedPaths(data, result)
+	if err != nil || len(paths) == 0 {
+		return err
+	}
+
+	unmapped := &UnmappedError{Endpoint: endpoint, Paths: paths}
+	if c.Strict == StrictError {
+		return unmapped
+	}
+
+	c.warningsMu.Lock()
+	if len(c.warnings) == MaxWarnings {
+		c.warnings = append(c.warnings[:0], c.warnings[1:]...)
+	}
+	c.warnings = append(c.warnings, unmapped)
+	c.warningsMu.Unlock()
+
+	return nil
+}
+
+// unmappedPaths returns the paths of all elements and attributes of data
+// which are lost when it is decoded into result. This is done by encoding
+// result again and comparing the paths of both documents.
+func unmappedPaths(data []byte, result interface{}) ([]string, error) {
+
+	var buf bytes.Buffer
+	e := xml.NewEncoder(&buf)
+	if err := e.EncodeElement(result, xml.StartElement{Name: xml.Name{Local: "metadata"}}); err != nil {
+		return nil, err
+	}
+	if err := e.Flush(); err != nil {
+		return nil, err
+	}
+
+	have, err := mmdPaths(data)
+	if err != nil {
+		return nil, err
+	}
+	mapped, err := mmdPaths(buf.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for p := range have {
+		if !mapped[p] {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+// mmdPaths returns the paths of all elements and attributes of an XML
+// document relative to its root element. Elements and attributes without
+// data, i.e. which only contain values like "", "0" or "false", are left out
+// since they are omitted on encoding.
+func mmdPaths(data []byte) (map[string]bool, error) {
+
+	type element struct {
+		path    string
+		hasData bool
+	}
+
+	paths := make(map[string]bool)
+	var stack []*element
+
+	d := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return paths, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			elem := &element{}
+			if len(stack) > 0 {
+				elem.path = strings.TrimPrefix(stack[len(stack)-1].path+"/"+t.Name.Local, "/")
+
+				for _, a := range t.Attr {
+					if a.Name.Space == "xmlns" || a.Name.Local == "xmlns" || !hasData(a.Value) {
+						continue
+					}
+					paths[elem.path+"/@"+a.Name.Local] = true
+					elem.hasData = true
+				}
+			}
+			stack = append(stack, elem)
+
+		case xml.CharData:
+			if len(stack) > 0 && hasData(string(t)) {
+				stack[len(stack)-1].hasData = true
+			}
+
+		case xml.EndElement:
+			elem := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			if elem.hasData && len(stack) > 0 {
+				paths[elem.path] = true
+				stack[len(stack)-1].hasData = true
+			}
+		}
+	}
+}
+
+// hasData reports whether an element or attribute value decodes to a non-zero
+// value.
+func hasData(v string) bool {
+	switch strings.TrimSpace(v) {
+	case "", "0", "false":
+		return false
+	}
+	return true
+}
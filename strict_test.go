@@ -0,0 +1,209 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update testdata/mmd-schema/unmapped.txt")
+
+// TODO use testdata from https://github.com/metabrainz/mmd-schema/tree/master/test-data/valid
+// (vendor it with testdata/mmd-schema/fetch.sh)
+func TestMMDSchemaTestData(t *testing.T) {
+
+	entities := map[string]func() MBLookupEntity{
+		"area":          func() MBLookupEntity { return &Area{} },
+		"artist":        func() MBLookupEntity { return &Artist{} },
+		"event":         func() MBLookupEntity { return &Event{} },
+		"instrument":    func() MBLookupEntity { return &Instrument{} },
+		"label":         func() MBLookupEntity { return &Label{} },
+		"place":         func() MBLookupEntity { return &Place{} },
+		"recording":     func() MBLookupEntity { return &Recording{} },
+		"release":       func() MBLookupEntity { return &Release{} },
+		"release-group": func() MBLookupEntity { return &ReleaseGroup{} },
+		"series":        func() MBLookupEntity { return &Series{} },
+		"work":          func() MBLookupEntity { return &Work{} },
+	}
+
+	// valid holds the upstream corpus vendored with fetch.sh, local the
+	// hand-written documents.
+	var files []string
+	for _, dir := range []string{"valid", "local"} {
+		matches, err := filepath.Glob(filepath.Join("testdata", "mmd-schema", dir, "*", "*.xml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(matches) == 0 {
+			t.Logf("no documents in testdata/mmd-schema/%s", dir)
+		}
+		files = append(files, matches...)
+	}
+	if len(files) == 0 {
+		t.Fatal("no mmd-schema test data found")
+	}
+
+	var report []string
+	for _, file := range files {
+		name, _ := filepath.Rel(filepath.Join("testdata", "mmd-schema"), file)
+		name = filepath.ToSlash(name)
+
+		newEntity, ok := entities[filepath.Base(filepath.Dir(file))]
+		if !ok {
+			// the upstream corpus also covers entities and requests
+			// gomusicbrainz does not support
+			t.Logf("%s: unsupported entity, skipped", name)
+			continue
+		}
+
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		c := &WS2Client{Strict: StrictError}
+		err = c.decode(strings.NewReader(string(data)), newEntity().lookupResult(), name)
+
+		switch e := err.(type) {
+		case nil:
+		case *UnmappedError:
+			for _, p := range e.Paths {
+				report = append(report, name+" "+p)
+			}
+		default:
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+
+	golden := filepath.Join("testdata", "mmd-schema", "unmapped.txt")
+	got := strings.Join(report, "\n") + "\n"
+
+	if *update {
+		if err := ioutil.WriteFile(golden, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != string(want) {
+		t.Errorf("unmapped MMD data differs from %s (run with -update to accept):\n%s",
+			golden, got)
+	}
+}
+
+func TestStrictMode(t *testing.T) {
+	setupHTTPTesting()
+	defer server.Close()
+	defer func() { client.Strict = StrictOff }()
+
+	mux.HandleFunc("/artist/c0b2500e-0cef-4130-869d-732b23ed9df5", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?>
+<metadata xmlns="http://musicbrainz.org/ns/mmd-2.0#">
+    <artist id="c0b2500e-0cef-4130-869d-732b23ed9df5" type="Person">
+        <name>Tori Amos</name>
+        <ended>false</ended>
+        <ipi-list><ipi>00232418939</ipi></ipi-list>
+        <alias-list count="0"/>
+        <tag-list count="1"><tag count="1" new-attr="x"><name>piano</name></tag></tag-list>
+    </artist>
+</metadata>`)
+	})
+
+	want := &UnmappedError{
+		Endpoint: "/artist/c0b2500e-0cef-4130-869d-732b23ed9df5",
+		Paths: []string{
+			"artist/ipi-list",
+			"artist/ipi-list/ipi",
+			"artist/tag-list/@count",
+			"artist/tag-list/tag/@new-attr",
+		},
+	}
+
+	client.Strict = StrictOff
+	if _, err := client.LookupArtist("c0b2500e-0cef-4130-869d-732b23ed9df5"); err != nil {
+		t.Fatal(err)
+	}
+	if w := client.Warnings(); w != nil {
+		t.Errorf("unexpected warnings %v", w)
+	}
+
+	client.Strict = StrictWarn
+	a, err := client.LookupArtist("c0b2500e-0cef-4130-869d-732b23ed9df5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Name != "Tori Amos" {
+		t.Errorf("artist not decoded: %+v", a)
+	}
+	if w := client.Warnings(); !reflect.DeepEqual(w, []*UnmappedError{want}) {
+		t.Error(requestDiff([]*UnmappedError{want}, w))
+	}
+	if w := client.Warnings(); w != nil {
+		t.Errorf("warnings not reset: %v", w)
+	}
+
+	client.Strict = StrictError
+	a, err = client.LookupArtist("c0b2500e-0cef-4130-869d-732b23ed9df5")
+	if !reflect.DeepEqual(err, want) {
+		t.Error(requestDiff(want, err))
+	}
+	if a.Name != "Tori Amos" {
+		t.Errorf("artist not decoded: %+v", a)
+	}
+}
+
+func TestStrictWarnLimit(t *testing.T) {
+
+	c := &WS2Client{Strict: StrictWarn}
+	doc := `<metadata><artist id="c0b2500e-0cef-4130-869d-732b23ed9df5"><ended>true</ended></artist></metadata>`
+
+	for i := 0; i < MaxWarnings+10; i++ {
+		if err := c.decode(strings.NewReader(doc), (&Artist{}).lookupResult(), fmt.Sprint(i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	warnings := c.Warnings()
+	if len(warnings) != MaxWarnings {
+		t.Fatalf("got %d warnings, want %d", len(warnings), MaxWarnings)
+	}
+	if first, last := warnings[0].Endpoint, warnings[MaxWarnings-1].Endpoint; first != "10" || last != fmt.Sprint(MaxWarnings+9) {
+		t.Errorf("got warnings %s to %s, want the last %d", first, last, MaxWarnings)
+	}
+	if len(c.Warnings()) != 0 {
+		t.Error("warnings not reset by Warnings")
+	}
+}
@@ -26,7 +26,12 @@
 package gomusicbrainz
 
 import (
+	"encoding/json"
 	"encoding/xml"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -50,13 +55,107 @@ type MBLookupEntity interface {
 	lookupResult() interface{}
 }
 
+// idValue is used to decode elements which carry a type MBID in their id
+// attribute e.g. <status id="...">Official</status>.
+type idValue struct {
+	ID    MBID   `xml:"id,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+// newIDValue returns the idValue to encode v and its type MBID or nil if both
+// are empty.
+func newIDValue(v string, id MBID) *idValue {
+	if v == "" && id == "" {
+		return nil
+	}
+	return &idValue{ID: id, Value: v}
+}
+
+// isZero reports whether v is the zero value of its type.
+func isZero(v interface{}) bool {
+	return reflect.ValueOf(v).IsZero()
+}
+
+// encodeOptional encodes v as element unless it is the zero value of its type.
+// encoding/xml does not support omitempty for structs, so MarshalXML methods
+// use it to omit optional elements. v must not implement xml.Marshaler itself.
+func encodeOptional(e *xml.Encoder, v interface{}, start xml.StartElement) error {
+	if isZero(v) {
+		return nil
+	}
+	return e.EncodeElement(v, start)
+}
+
+// xmlList encodes a list element e.g. alias-list. encoding/xml writes the
+// parent of a field tagged "alias-list>alias" even if the slice is empty, so
+// MarshalXML methods shadow such fields with an *xmlList, which is nil for
+// empty lists, see newXMLList.
+type xmlList[T any] struct {
+	item  string
+	items []T
+}
+
+// newXMLList returns the list to encode items as elements named item or nil
+// if there are no items.
+func newXMLList[T any](item string, items []T) *xmlList[T] {
+	if len(items) == 0 {
+		return nil
+	}
+	return &xmlList[T]{item: item, items: items}
+}
+
+func (l *xmlList[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, v := range l.items {
+		if err := e.EncodeElement(v, xml.StartElement{Name: xml.Name{Local: l.item}}); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// extNamespace is the namespace of the ext:score attribute of search results.
+const extNamespace = "http://musicbrainz.org/ns/ext#-2.0"
+
+// scoreAttr returns the value of the ext:score attribute of a search result
+// element.
+func scoreAttr(start xml.StartElement) int {
+	for _, a := range start.Attr {
+		if a.Name.Space == extNamespace && a.Name.Local == "score" {
+			score, _ := strconv.Atoi(a.Value)
+			return score
+		}
+	}
+	return 0
+}
+
+// newScoreAttr returns the ext:score attribute for a search result element.
+func newScoreAttr(score int) xml.Attr {
+	return xml.Attr{
+		Name:  xml.Name{Space: extNamespace, Local: "score"},
+		Value: strconv.Itoa(score),
+	}
+}
+
 // MBCoordinates represents a tuple of latitude,longitude values.
 type MBCoordinates struct {
-	Lat string `xml:"latitude"`
-	Lng string `xml:"longitude"`
+	Lat string `xml:"latitude,omitempty"`
+	Lng string `xml:"longitude,omitempty"`
+}
+
+// MarshalXML omits unknown coordinates.
+func (c MBCoordinates) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	type coordinates MBCoordinates
+	return encodeOptional(e, coordinates(c), start)
 }
 
 // ScoreMap maps addresses of search request results to its scores.
+//
+// Deprecated: Scores are lost if an entity is copied. Use the Results field
+// of search responses instead, see Scored.
 type ScoreMap map[interface{}]int
 
 type ISO31662Code string
@@ -85,10 +184,12 @@ type BrainzTime struct {
 	Accuracy BrainzTimeAccuracy
 }
 
-func (t *BrainzTime) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
-	var v string
+// ParseBrainzTime parses a MusicBrainz date in one of the forms "2006",
+// "2006-01" or "2006-01-02". An empty string results in a zero BrainzTime.
+func ParseBrainzTime(v string) (BrainzTime, error) {
+
+	var t BrainzTime
 	var err error
-	d.DecodeElement(&v, &start)
 
 	if v != "" {
 		switch strings.Count(v, "-") {
@@ -101,12 +202,131 @@ func (t *BrainzTime) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error
 		case 2:
 			t.Time, err = time.Parse("2006-01-02", v)
 			t.Accuracy = Day
+		default:
+			err = fmt.Errorf("invalid date %q", v)
 		}
 	}
 
+	return t, err
+}
+
+func (t *BrainzTime) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var v string
+	var err error
+
+	if err = d.DecodeElement(&v, &start); err != nil {
+		return err
+	}
+
+	*t, err = ParseBrainzTime(v)
+	return err
+}
+
+// MarshalXML encodes t in the format given by its accuracy. Zero times are
+// omitted.
+func (t BrainzTime) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if t.IsZero() {
+		return nil
+	}
+	return e.EncodeElement(t.String(), start)
+}
+
+// MarshalJSON encodes t as JSON string in the format given by its accuracy.
+// Zero times are encoded as null.
+func (t BrainzTime) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.String())
+}
+
+func (t *BrainzTime) UnmarshalJSON(data []byte) error {
+
+	if string(data) == "null" {
+		*t = BrainzTime{}
+		return nil
+	}
+
+	var v string
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	var err error
+	*t, err = ParseBrainzTime(v)
 	return err
 }
 
+// String returns t formatted according to its accuracy e.g. "2006" for Year
+// or "2006-01" for Month. Zero times result in an empty string.
+func (t BrainzTime) String() string {
+
+	if t.IsZero() {
+		return ""
+	}
+
+	switch t.Accuracy {
+	case Year:
+		return t.Format("2006")
+	case Month:
+		return t.Format("2006-01")
+	default:
+		return t.Format("2006-01-02")
+	}
+}
+
+// IsZero reports whether t represents an unknown date.
+func (t BrainzTime) IsZero() bool {
+	return t.Time.IsZero()
+}
+
+// end returns the exclusive end of the period t represents e.g. 1970-01-01
+// for "1969".
+func (t BrainzTime) end() time.Time {
+	switch t.Accuracy {
+	case Year:
+		return t.AddDate(1, 0, 0)
+	case Month:
+		return t.AddDate(0, 1, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+// The following methods treat a BrainzTime as the period of time it
+// represents, so "1969" is the whole year 1969 and "1969-08-15" a single day.
+// They return false if any of the times is zero.
+
+// Before reports whether the period of t ends before u begins.
+func (t BrainzTime) Before(u BrainzTime) bool {
+	if t.IsZero() || u.IsZero() {
+		return false
+	}
+	return !t.end().After(u.Time)
+}
+
+// After reports whether the period of t begins after u ends.
+func (t BrainzTime) After(u BrainzTime) bool {
+	return u.Before(t)
+}
+
+// Contains reports whether the period of u lies within the period of t, e.g.
+// "1969" contains "1969-08-15".
+func (t BrainzTime) Contains(u BrainzTime) bool {
+	if t.IsZero() || u.IsZero() {
+		return false
+	}
+	return !u.Time.Before(t.Time) && !u.end().After(t.end())
+}
+
+// Overlaps reports whether the periods of t and u have any time in common.
+func (t BrainzTime) Overlaps(u BrainzTime) bool {
+	if t.IsZero() || u.IsZero() {
+		return false
+	}
+	return t.Time.Before(u.end()) && u.Time.Before(t.end())
+}
+
 // WS2ListResponse is a abstract common type that provides the Count and Offset
 // fields for ervery list response.
 type WS2ListResponse struct {
@@ -119,43 +339,237 @@ type WS2ListResponse struct {
 type Lifespan struct {
 	Begin BrainzTime `xml:"begin"`
 	End   BrainzTime `xml:"end"`
-	Ended bool       `xml:"ended"`
+	Ended bool       `xml:"ended,omitempty"`
+}
+
+// MarshalXML omits unknown life spans.
+func (l Lifespan) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	type lifespan Lifespan
+	return encodeOptional(e, lifespan(l), start)
+}
+
+// Duration returns the length of the life span. Ongoing life spans (Ended is
+// false and End is unknown) are measured up to now. 0 is returned if the begin
+// or the end of an ended life span is unknown. Partial dates are treated as
+// their first day.
+func (l *Lifespan) Duration() time.Duration {
+	end, ok := l.endAt(time.Now())
+	if !ok {
+		return 0
+	}
+	return end.Sub(l.Begin.Time)
+}
+
+// Age returns the number of full years the life span lasted at the given
+// time, e.g. the age of a person or how long a group existed. ok is false if
+// the begin or the end of an ended life span is unknown, or if at is before
+// the begin. Partial dates are treated as their first day.
+func (l *Lifespan) Age(at time.Time) (years int, ok bool) {
+
+	end, ok := l.endAt(at)
+	if !ok || end.Before(l.Begin.Time) {
+		return 0, false
+	}
+
+	years = end.Year() - l.Begin.Year()
+	if end.Month() < l.Begin.Month() ||
+		end.Month() == l.Begin.Month() && end.Day() < l.Begin.Day() {
+		years--
+	}
+	return years, true
+}
+
+// endAt returns the end of the life span or at for ongoing life spans.
+func (l *Lifespan) endAt(at time.Time) (time.Time, bool) {
+
+	if l.Begin.IsZero() {
+		return time.Time{}, false
+	}
+	if !l.End.IsZero() && l.End.Time.Before(at) {
+		return l.End.Time, true
+	}
+	if l.Ended && l.End.IsZero() {
+		return time.Time{}, false
+	}
+	return at, true
 }
 
 // Alias is a type for aliases/misspellings of artists, works, areas, labels
 // and places.
 type Alias struct {
 	Name     string `xml:",chardata"`
-	SortName string `xml:"sort-name,attr"`
-	Locale   string `xml:"locale,attr"`
-	Type     string `xml:"type,attr"`
-	Primary  string `xml:"primary,attr"`
+	SortName string `xml:"sort-name,attr,omitempty"`
+	Locale   string `xml:"locale,attr,omitempty"`
+	Type     string `xml:"type,attr,omitempty"`
+	Primary  string `xml:"primary,attr,omitempty"`
 }
 
 // Medium represents one of the physical, separate things you would get when
 // you buy something in a record store e.g. CDs, vinyls, etc. Mediums are
 // always included in a release. For more information visit
 // https://musicbrainz.org/doc/Medium
+//
+// Tracks is only populated if tracks are included in the request (e.g.
+// inc=recordings for release lookups), TrackCount is always set by WS2. The
+// hidden pregap track of a CD and data tracks are not part of Tracks.
 type Medium struct {
-	Format   string `xml:"format"`
-	Position int    `xml:"position"`
-	//DiscList TODO implement type
-	Tracks []*Track `xml:"track-list>track"`
+	Title       string   `xml:"title,omitempty"`
+	Format      string   `xml:"format,omitempty"`
+	FormatID    MBID     `xml:"-"`
+	Position    int      `xml:"position,omitempty"`
+	Discs       []*Disc  `xml:"disc-list>disc"`
+	DiscCount   int      `xml:"-"`
+	Pregap      *Track   `xml:"pregap"`
+	Tracks      []*Track `xml:"track-list>track"`
+	TrackCount  int      `xml:"-"`
+	TrackOffset int      `xml:"-"`
+	DataTracks  []*Track `xml:"data-track-list>track"`
+}
+
+// UnmarshalXML is needed to implement XMLUnmarshaler for mediums to decode the
+// count attributes of the disc-list and track-list and the MBID of the format.
+func (m *Medium) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+
+	type medium Medium
+	var res struct {
+		*medium
+		Format   idValue `xml:"format"`
+		DiscList struct {
+			Count int     `xml:"count,attr"`
+			Discs []*Disc `xml:"disc"`
+		} `xml:"disc-list"`
+		TrackList struct {
+			Count  int      `xml:"count,attr"`
+			Offset int      `xml:"offset,attr"`
+			Tracks []*Track `xml:"track"`
+		} `xml:"track-list"`
+	}
+	res.medium = (*medium)(m)
+
+	if err := d.DecodeElement(&res, &start); err != nil {
+		return err
+	}
+
+	m.Format, m.FormatID = res.Format.Value, res.Format.ID
+	m.Discs = res.DiscList.Discs
+	m.DiscCount = res.DiscList.Count
+	m.Tracks = res.TrackList.Tracks
+	m.TrackCount = res.TrackList.Count
+	m.TrackOffset = res.TrackList.Offset
+
+	return nil
+}
+
+// MarshalXML is the counterpart of UnmarshalXML and encodes the count
+// attributes of the disc-list and track-list and the MBID of the format. Empty
+// lists are omitted.
+func (m Medium) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+
+	type medium Medium
+	type discList struct {
+		Count int     `xml:"count,attr"`
+		Discs []*Disc `xml:"disc"`
+	}
+	type trackList struct {
+		Count  int      `xml:"count,attr"`
+		Offset int      `xml:"offset,attr,omitempty"`
+		Tracks []*Track `xml:"track"`
+	}
+	res := struct {
+		*medium
+		Format     *idValue         `xml:"format"`
+		DiscList   *discList        `xml:"disc-list"`
+		TrackList  *trackList       `xml:"track-list"`
+		DataTracks *xmlList[*Track] `xml:"data-track-list"`
+	}{
+		medium:     (*medium)(&m),
+		Format:     newIDValue(m.Format, m.FormatID),
+		DataTracks: newXMLList("track", m.DataTracks),
+	}
+
+	if len(m.Discs) > 0 || m.DiscCount > 0 {
+		res.DiscList = &discList{Count: m.DiscCount, Discs: m.Discs}
+	}
+	if len(m.Tracks) > 0 || m.TrackCount > 0 || m.TrackOffset > 0 {
+		res.TrackList = &trackList{
+			Count:  m.TrackCount,
+			Offset: m.TrackOffset,
+			Tracks: m.Tracks,
+		}
+	}
+
+	return e.EncodeElement(res, start)
+}
+
+// TrackByNumber returns the track with the given number as printed on the
+// medium (e.g. "3" or "A2") or nil. The pregap and data tracks are included
+// in the search.
+func (m *Medium) TrackByNumber(number string) *Track {
+
+	for _, t := range m.Tracks {
+		if t.Number == number {
+			return t
+		}
+	}
+	for _, t := range m.DataTracks {
+		if t.Number == number {
+			return t
+		}
+	}
+	if m.Pregap != nil && m.Pregap.Number == number {
+		return m.Pregap
+	}
+	return nil
+}
+
+// Disc represents a CD with a disc ID that is attached to a medium. The disc
+// ID is calculated from the table of contents (TOC) which consists of the
+// total number of sectors and the sector offsets of the tracks. See
+// https://musicbrainz.org/doc/Disc_ID
+type Disc struct {
+	ID      string `xml:"id,attr"`
+	Sectors int    `xml:"sectors,omitempty"`
+	Offsets []int  `xml:"offset-list>offset"`
+}
+
+// MarshalXML omits empty offset lists.
+func (d Disc) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+
+	type disc Disc
+	res := struct {
+		*disc
+		Offsets *xmlList[int] `xml:"offset-list"`
+	}{
+		disc:    (*disc)(&d),
+		Offsets: newXMLList("offset", d.Offsets),
+	}
+
+	return e.EncodeElement(res, start)
 }
 
 // Track represents a recording on a particular release (or, more exactly, on
-// a particular medium). See https://musicbrainz.org/doc/Track
+// a particular medium). Title and ArtistCredit are the track title and artist
+// as printed on the release, which can differ from the ones of the recording.
+// Length is given in milliseconds. See https://musicbrainz.org/doc/Track
 type Track struct {
-	ID        MBID      `xml:"id,attr"`
-	Position  int       `xml:"position"`
-	Number    string    `xml:"number"`
-	Length    int       `xml:"length"`
-	Recording Recording `xml:"recording"`
+	ID           MBID         `xml:"id,attr"`
+	Position     int          `xml:"position,omitempty"`
+	Number       string       `xml:"number,omitempty"`
+	Title        string       `xml:"title,omitempty"`
+	Length       int          `xml:"length,omitempty"`
+	ArtistCredit ArtistCredit `xml:"artist-credit"`
+	Recording    Recording    `xml:"recording"`
 }
 
 type TextRepresentation struct {
-	Language string `xml:"language"`
-	Script   string `xml:"script"`
+	Language string `xml:"language,omitempty"`
+	Script   string `xml:"script,omitempty"`
+}
+
+// MarshalXML omits empty text representations.
+func (t TextRepresentation) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	type textRepresentation TextRepresentation
+	return encodeOptional(e, textRepresentation(t), start)
 }
 
 // ArtistCredit is either used to link multiple artists to one
@@ -165,8 +579,45 @@ type ArtistCredit struct {
 	NameCredits []NameCredit `xml:"name-credit"`
 }
 
+// MarshalXML omits empty artist credits.
+func (a ArtistCredit) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	type artistCredit ArtistCredit
+	return encodeOptional(e, artistCredit(a), start)
+}
+
+// NameCredit is an artist of an ArtistCredit. Name is the name the artist is
+// credited with if it differs from the artist's name. JoinPhrase joins it with
+// the next credit, e.g. " & " or " feat. ".
 type NameCredit struct {
-	Artist Artist `xml:"artist"`
+	Artist     Artist `xml:"artist"`
+	Name       string `xml:"name,omitempty"`
+	JoinPhrase string `xml:"joinphrase,attr,omitempty"`
+}
+
+// Name returns the credited names of a including the join phrases, e.g.
+// "Tori Amos feat. Damien Rice".
+func (a ArtistCredit) Name() string {
+	var b strings.Builder
+	for _, nc := range a.NameCredits {
+		if nc.Name != "" {
+			b.WriteString(nc.Name)
+		} else {
+			b.WriteString(nc.Artist.Name)
+		}
+		b.WriteString(nc.JoinPhrase)
+	}
+	return b.String()
+}
+
+// SortName returns the sort names of the artists of a joined by the join
+// phrases, e.g. "Amos, Tori feat. Rice, Damien".
+func (a ArtistCredit) SortName() string {
+	var b strings.Builder
+	for _, nc := range a.NameCredits {
+		b.WriteString(nc.Artist.SortName)
+		b.WriteString(nc.JoinPhrase)
+	}
+	return b.String()
 }
 
 // Relation describes a relationship between different MusicBrainz entities.
@@ -178,15 +629,15 @@ type Relation interface {
 
 // RelationAbstract is the common abstract type for Relations.
 type RelationAbstract struct {
-	Type        string     `xml:"type,attr"`
-	TypeID      MBID       `xml:"type-id,attr"`
-	Target      string     `xml:"target"`
-	TargetID    MBID       `xml:"target-id,attr"`
-	OrderingKey int        `xml:"ordering-key"`
-	Direction   string     `xml:"direction"`
+	Type        string     `xml:"type,attr,omitempty"`
+	TypeID      MBID       `xml:"type-id,attr,omitempty"`
+	Target      string     `xml:"target,omitempty"`
+	TargetID    MBID       `xml:"target-id,attr,omitempty"`
+	OrderingKey int        `xml:"ordering-key,omitempty"`
+	Direction   string     `xml:"direction,omitempty"`
 	Begin       BrainzTime `xml:"begin"`
 	End         BrainzTime `xml:"end"`
-	Ended       bool       `xml:"ended"`
+	Ended       bool       `xml:"ended,omitempty"`
 }
 
 func (r *RelationAbstract) TypeOf() string {
@@ -302,3 +753,30 @@ func (r *TargetRelationsMap) UnmarshalXML(d *xml.Decoder, start xml.StartElement
 
 	return nil
 }
+
+// MarshalXML is the counterpart of UnmarshalXML and encodes one relation-list
+// element per target type.
+func (r TargetRelationsMap) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+
+	targetTypes := make([]string, 0, len(r))
+	for t := range r {
+		targetTypes = append(targetTypes, t)
+	}
+	sort.Strings(targetTypes)
+
+	for _, t := range targetTypes {
+		list := start
+		list.Attr = append(list.Attr[:len(list.Attr):len(list.Attr)],
+			xml.Attr{Name: xml.Name{Local: "target-type"}, Value: t})
+
+		rels := struct {
+			Relations []Relation `xml:"relation"`
+		}{r[t]}
+
+		if err := e.EncodeElement(rels, list); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
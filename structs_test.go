@@ -0,0 +1,150 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+)
+
+func mustParseBrainzTime(t *testing.T, v string) BrainzTime {
+	bt, err := ParseBrainzTime(v)
+	if err != nil {
+		t.Fatalf("ParseBrainzTime(%q): %v", v, err)
+	}
+	return bt
+}
+
+func TestBrainzTimeString(t *testing.T) {
+
+	for _, v := range []string{"", "1969", "1969-08", "1969-08-15"} {
+		if s := mustParseBrainzTime(t, v).String(); s != v {
+			t.Errorf("ParseBrainzTime(%q).String() = %q", v, s)
+		}
+	}
+
+	for _, v := range []string{"69", "1969-8-15-1", "1969-13"} {
+		if _, err := ParseBrainzTime(v); err == nil {
+			t.Errorf("ParseBrainzTime(%q): expected error", v)
+		}
+	}
+}
+
+func TestBrainzTimeMarshal(t *testing.T) {
+
+	type dates struct {
+		XMLName xml.Name   `xml:"dates" json:"-"`
+		Begin   BrainzTime `xml:"begin" json:"begin"`
+		End     BrainzTime `xml:"end" json:"end"`
+	}
+	in := dates{Begin: mustParseBrainzTime(t, "1969-08")}
+
+	x, err := xml.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "<dates><begin>1969-08</begin></dates>"; string(x) != want {
+		t.Errorf("xml: got %s, want %s", x, want)
+	}
+
+	j, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"begin":"1969-08","end":null}`; string(j) != want {
+		t.Errorf("json: got %s, want %s", j, want)
+	}
+
+	var out dates
+	if err := json.Unmarshal(j, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out != in {
+		t.Errorf("json round trip: got %+v, want %+v", out, in)
+	}
+}
+
+func TestBrainzTimeCompare(t *testing.T) {
+
+	year := mustParseBrainzTime(t, "1969")
+	month := mustParseBrainzTime(t, "1969-08")
+	day := mustParseBrainzTime(t, "1969-08-15")
+	next := mustParseBrainzTime(t, "1970-01-01")
+
+	if !year.Contains(day) || !year.Contains(month) || !month.Contains(day) {
+		t.Error("expected 1969 ⊇ 1969-08 ⊇ 1969-08-15")
+	}
+	if day.Contains(year) {
+		t.Error("1969-08-15 must not contain 1969")
+	}
+	if !year.Overlaps(day) || year.Overlaps(next) {
+		t.Error("unexpected overlap result")
+	}
+	if !year.Before(next) || !next.After(year) {
+		t.Error("expected 1969 before 1970-01-01")
+	}
+	if year.Before(day) || day.Before(year) {
+		t.Error("overlapping periods must not be ordered")
+	}
+	if year.Before(BrainzTime{}) || (BrainzTime{}).Contains(day) {
+		t.Error("zero times must not compare")
+	}
+}
+
+func TestLifespan(t *testing.T) {
+
+	l := Lifespan{
+		Begin: mustParseBrainzTime(t, "1942-06-18"),
+		End:   mustParseBrainzTime(t, "2000-06-17"),
+		Ended: true,
+	}
+
+	if age, ok := l.Age(time.Now()); !ok || age != 57 {
+		t.Errorf("Age = %d, %v, want 57, true", age, ok)
+	}
+	at := time.Date(1990, 6, 18, 0, 0, 0, 0, time.UTC)
+	if age, ok := l.Age(at); !ok || age != 48 {
+		t.Errorf("Age(%v) = %d, %v, want 48, true", at, age, ok)
+	}
+	if d := l.Duration(); d != l.End.Sub(l.Begin.Time) {
+		t.Errorf("Duration = %v", d)
+	}
+
+	l.End = BrainzTime{}
+	if _, ok := l.Age(time.Now()); ok {
+		t.Error("Age of ended life span without end must not be known")
+	}
+	if d := l.Duration(); d != 0 {
+		t.Errorf("Duration = %v, want 0", d)
+	}
+
+	l.Ended = false
+	if d := l.Duration(); d <= 0 {
+		t.Errorf("Duration of ongoing life span = %v, want > 0", d)
+	}
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+)
+
+const mmdNamespace = "http://musicbrainz.org/ns/mmd-2.0#"
+
+// submissionBody is the MMD document POSTed to WS2 submission endpoints. It
+// groups entities in lists by entity type e.g. artist-list, recording-list.
+type submissionBody struct {
+	XMLName xml.Name `xml:"metadata"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Lists   []*submissionList
+}
+
+func newSubmissionBody() *submissionBody {
+	return &submissionBody{Xmlns: mmdNamespace}
+}
+
+type submissionList struct {
+	XMLName  xml.Name
+	Entities []*submissionEntity
+}
+
+type submissionEntity struct {
+	XMLName     xml.Name
+	ID          MBID                   `xml:"id,attr"`
+	UserRating  *int                   `xml:"user-rating,omitempty"`
+	UserTagList *submissionUserTagList `xml:"user-tag-list"`
+	ISRCList    *submissionISRCList    `xml:"isrc-list"`
+	Barcode     string                 `xml:"barcode,omitempty"`
+}
+
+type submissionUserTagList struct {
+	UserTags []struct {
+		Name string `xml:"name"`
+	} `xml:"user-tag"`
+}
+
+type submissionISRCList struct {
+	Count int `xml:"count,attr"`
+	ISRCs []struct {
+		ID string `xml:"id,attr"`
+	} `xml:"isrc"`
+}
+
+// entity returns the submission element for the given entity and creates it
+// (and the list containing it) if necessary. An error is returned if the
+// entity has no valid MBID, which WS2 would reject with an opaque 400.
+func (b *submissionBody) entity(e MBEntity) (*submissionEntity, error) {
+
+	if e == nil || isZero(e) {
+		return nil, errors.New("no entity to submit for")
+	}
+	id, err := ParseMBID(string(e.Id()))
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimPrefix(e.apiEndpoint(), "/")
+
+	var list *submissionList
+	for _, l := range b.Lists {
+		if l.XMLName.Local == name+"-list" {
+			list = l
+			break
+		}
+	}
+	if list == nil {
+		list = &submissionList{XMLName: xml.Name{Local: name + "-list"}}
+		b.Lists = append(b.Lists, list)
+	}
+
+	for _, v := range list.Entities {
+		if v.ID == id {
+			return v, nil
+		}
+	}
+
+	se := &submissionEntity{XMLName: xml.Name{Local: name}, ID: id}
+	list.Entities = append(list.Entities, se)
+
+	return se, nil
+}
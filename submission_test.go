@@ -0,0 +1,201 @@
+/*
+ * Copyright (c) 2014 Michael Wendland
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a
+ * copy of this software and associated documentation files (the "Software"),
+ * to deal in the Software without restriction, including without limitation
+ * the rights to use, copy, modify, merge, publish, distribute, sublicense,
+ * and/or sell copies of the Software, and to permit persons to whom the
+ * Software is furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+ * FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS
+ * IN THE SOFTWARE.
+ *
+ * 	Authors:
+ * 		Michael Wendland <michael@michiwend.com>
+ */
+
+package gomusicbrainz
+
+import (
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+// serveSubmission serves a digest authenticated submission endpoint that
+// checks the request body against want.
+func serveSubmission(endpoint, want string, t *testing.T) {
+
+	serveDigestAuth(endpoint, t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("expected POST request, got %s", r.Method)
+		}
+		if c := r.URL.Query().Get("client"); c != "Application Name-Version" {
+			t.Errorf("unexpected client parameter %q", c)
+		}
+
+		body, _ := ioutil.ReadAll(r.Body)
+		want = `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + want
+		if string(body) != want {
+			t.Errorf("unexpected request body\nwant: %s\ngot:  %s", want, body)
+		}
+
+		w.Write([]byte(`<metadata><message><text>OK</text></message></metadata>`))
+	})
+}
+
+func TestSubmitTags(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+	client.SetCredentials(testUsername, testPassword)
+
+	serveSubmission("/tag",
+		`<metadata xmlns="http://musicbrainz.org/ns/mmd-2.0#">`+
+			`<artist-list><artist id="10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8">`+
+			`<user-tag-list><user-tag><name>trip hop</name></user-tag><user-tag><name>bristol</name></user-tag></user-tag-list>`+
+			`</artist></artist-list>`+
+			`<release-group-list><release-group id="70664047-2545-4e46-b75f-4556f2a7b83e">`+
+			`<user-tag-list></user-tag-list>`+
+			`</release-group></release-group-list>`+
+			`</metadata>`, t)
+
+	err := client.SubmitTags(
+		TagSubmission{&Artist{ID: "10adbe5e-a2c0-4bf3-8249-2b4cbf6e6ca8"}, []string{"trip hop", " bristol"}},
+		TagSubmission{&ReleaseGroup{ID: "70664047-2545-4e46-b75f-4556f2a7b83e"}, nil},
+	)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSubmitISRCs(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+	client.SetCredentials(testUsername, testPassword)
+
+	serveSubmission("/recording",
+		`<metadata xmlns="http://musicbrainz.org/ns/mmd-2.0#">`+
+			`<recording-list><recording id="a2e3c1a4-2c8a-4ad1-9d39-ef6b0ab12ef6">`+
+			`<isrc-list count="2"><isrc id="GBAAA9400018"></isrc><isrc id="GBAAA9400019"></isrc></isrc-list>`+
+			`</recording></recording-list>`+
+			`</metadata>`, t)
+
+	err := client.SubmitISRCs(ISRCSubmission{
+		Recording: "a2e3c1a4-2c8a-4ad1-9d39-ef6b0ab12ef6",
+		ISRCs:     []string{"GB-AAA-94-00018", "gbaaa9400019"},
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = client.SubmitISRCs(ISRCSubmission{
+		Recording: "a2e3c1a4-2c8a-4ad1-9d39-ef6b0ab12ef6",
+		ISRCs:     []string{"GBAAA94"},
+	})
+	if err == nil {
+		t.Error("expected error for invalid ISRC")
+	}
+}
+
+func TestSubmitBarcodes(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+	client.SetCredentials(testUsername, testPassword)
+
+	serveSubmission("/release",
+		`<metadata xmlns="http://musicbrainz.org/ns/mmd-2.0#">`+
+			`<release-list><release id="07832b54-8266-47d5-bb0e-62c7f2cf5da5">`+
+			`<barcode>724383988327</barcode>`+
+			`</release></release-list>`+
+			`</metadata>`, t)
+
+	err := client.SubmitBarcodes(BarcodeSubmission{
+		Release: "07832b54-8266-47d5-bb0e-62c7f2cf5da5",
+		Barcode: "724383988327",
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = client.SubmitBarcodes(BarcodeSubmission{
+		Release: "07832b54-8266-47d5-bb0e-62c7f2cf5da5",
+		Barcode: "724383988328",
+	})
+	if err == nil {
+		t.Error("expected error for invalid barcode")
+	}
+}
+
+func TestSubmissionWS2Error(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+	client.SetCredentials(testUsername, testPassword)
+
+	serveDigestAuth("/release", t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>` +
+			`<error><text>Invalid barcode</text>` +
+			`<text>For usage, please see: https://musicbrainz.org/development/mmd</text></error>`))
+	})
+
+	err := client.SubmitBarcodes(BarcodeSubmission{
+		Release: "07832b54-8266-47d5-bb0e-62c7f2cf5da5",
+		Barcode: "724383988327",
+	})
+
+	ws2err, ok := err.(*WS2Error)
+	if !ok {
+		t.Fatalf("expected *WS2Error, got %#v", err)
+	}
+	if ws2err.StatusCode != http.StatusBadRequest || len(ws2err.Messages) != 2 ||
+		ws2err.Messages[0] != "Invalid barcode" {
+		t.Errorf("unexpected error %#v", ws2err)
+	}
+}
+
+func TestSubmissionInvalidMBID(t *testing.T) {
+
+	setupHTTPTesting()
+	defer server.Close()
+	client.SetCredentials(testUsername, testPassword)
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+	})
+
+	for _, id := range []MBID{"", "07832b54-8266-47d5-bb0e", "07832b54-8266-47d5-bb0e-62c7f2cf5da5/../x"} {
+		if err := client.SubmitBarcodes(BarcodeSubmission{Release: id, Barcode: "724383988327"}); err == nil {
+			t.Errorf("SubmitBarcodes(%q): expected error", id)
+		}
+		if err := client.SubmitISRCs(ISRCSubmission{Recording: id, ISRCs: []string{"GBAAA9400001"}}); err == nil {
+			t.Errorf("SubmitISRCs(%q): expected error", id)
+		}
+		if err := client.SubmitTags(TagSubmission{Entity: &Artist{ID: id}, Tags: []string{"rock"}}); err == nil {
+			t.Errorf("SubmitTags(%q): expected error", id)
+		}
+		if err := client.SubmitRatings(RatingSubmission{&Artist{ID: id}, 20}); err == nil {
+			t.Errorf("SubmitRatings(%q): expected error", id)
+		}
+	}
+
+	if err := client.SubmitTags(TagSubmission{Tags: []string{"rock"}}); err == nil {
+		t.Error("SubmitTags without entity: expected error")
+	}
+	var none *Artist
+	if err := client.SubmitTags(TagSubmission{Entity: none, Tags: []string{"rock"}}); err == nil {
+		t.Error("SubmitTags with nil entity: expected error")
+	}
+}
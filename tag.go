@@ -25,8 +25,53 @@
 
 package gomusicbrainz
 
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
 // Tag is the common type for Tags.
 type Tag struct {
 	Count int    `xml:"count,attr"`
 	Name  string `xml:"name"`
 }
+
+// TagSubmission assigns the user tags for an entity. Submitting tags replaces
+// all tags the user has previously applied to the entity, so an empty Tags
+// slice removes them.
+type TagSubmission struct {
+	Entity MBEntity
+	Tags   []string
+}
+
+// SubmitTags submits user tags for the given entities. This requires
+// authentication, see SetCredentials. For more information visit
+// https://musicbrainz.org/doc/Development/XML_Web_Service/Version_2#Tags
+func (c *WS2Client) SubmitTags(tags ...TagSubmission) error {
+
+	body := newSubmissionBody()
+
+	for _, t := range tags {
+		se, err := body.entity(t.Entity)
+		if err != nil {
+			return err
+		}
+		if se.UserTagList == nil {
+			// an empty list removes all user tags of the entity
+			se.UserTagList = &submissionUserTagList{}
+		}
+
+		for _, name := range t.Tags {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				return fmt.Errorf("empty tag for %s", se.ID)
+			}
+			se.UserTagList.UserTags = append(se.UserTagList.UserTags, struct {
+				Name string `xml:"name"`
+			}{name})
+		}
+	}
+
+	return c.authRequest("POST", nil, body, url.Values{"client": {c.clientID}}, "/tag")
+}
@@ -0,0 +1,34 @@
+#!/bin/sh
+#
+# fetch.sh vendors the valid test-data corpus of mmd-schema into valid/
+# together with its license and a SOURCE note naming the upstream commit.
+# Run `go test -run TestMMDSchemaTestData -update` afterwards and review the
+# changes of unmapped.txt.
+#
+# Usage: testdata/mmd-schema/fetch.sh [ref]
+
+set -e
+
+repo=https://github.com/metabrainz/mmd-schema.git
+ref=${1:-master}
+dir=$(cd "$(dirname "$0")" && pwd)
+
+tmp=$(mktemp -d)
+trap 'rm -rf "$tmp"' EXIT
+
+git clone --quiet "$repo" "$tmp/mmd-schema"
+git -C "$tmp/mmd-schema" checkout --quiet "$ref"
+
+rm -rf "$dir/valid"
+cp -R "$tmp/mmd-schema/test-data/valid" "$dir/valid"
+
+for f in LICENSE LICENSE.txt LICENSE.md COPYING; do
+	if [ -f "$tmp/mmd-schema/$f" ]; then
+		cp "$tmp/mmd-schema/$f" "$dir/valid/$f"
+	fi
+done
+
+cat > "$dir/valid/SOURCE" <<SOURCE
+test-data/valid of $repo
+at commit $(git -C "$tmp/mmd-schema" rev-parse HEAD)
+SOURCE
@@ -25,15 +25,107 @@
 
 package gomusicbrainz
 
+import (
+	"context"
+	"encoding/xml"
+)
+
+// Work represents a distinct intellectual or artistic creation, which can be
+// expressed in the form of one or more audio recordings. See
+// https://musicbrainz.org/doc/Work
 type Work struct {
-	//TODO implement
+	ID             MBID               `xml:"id,attr"`
+	Type           string             `xml:"type,attr,omitempty"`
+	Title          string             `xml:"title,omitempty"`
+	Language       string             `xml:"language,omitempty"`
+	ISWCs          []string           `xml:"iswc-list>iswc"`
+	Disambiguation string             `xml:"disambiguation,omitempty"`
+	Aliases        []*Alias           `xml:"alias-list>alias"`
+	Tags           []*Tag             `xml:"tag-list>tag"`
+	Rating         Rating             `xml:"rating"`
+	UserRating     int                `xml:"user-rating,omitempty"`
+	Relations      TargetRelationsMap `xml:"relation-list"`
+}
+
+// MarshalXML omits the iswc-list, alias-list and tag-list if they are empty.
+func (mbe Work) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+
+	type work Work
+	res := struct {
+		*work
+		ISWCs   *xmlList[string] `xml:"iswc-list"`
+		Aliases *xmlList[*Alias] `xml:"alias-list"`
+		Tags    *xmlList[*Tag]   `xml:"tag-list"`
+	}{
+		work:    (*work)(&mbe),
+		ISWCs:   newXMLList("iswc", mbe.ISWCs),
+		Aliases: newXMLList("alias", mbe.Aliases),
+		Tags:    newXMLList("tag", mbe.Tags),
+	}
+
+	return e.EncodeElement(res, start)
+}
+
+func (mbe *Work) lookupResult() interface{} {
+	return &lookupDoc{mbe}
+}
+
+func (mbe *Work) apiEndpoint() string {
+	return "/work"
 }
 
+func (mbe *Work) Id() MBID {
+	return mbe.ID
+}
+
+// LookupWork performs a work lookup request for the given MBID.
+func (c *WS2Client) LookupWork(id MBID, inc ...string) (*Work, error) {
+	a := &Work{ID: id}
+	err := c.Lookup(a, inc...)
+
+	return a, err
+}
+
+// SearchWork queries MusicBrainz´ Search Server for Works.
+//
+// Possible search fields to provide in searchTerm are:
+//
+//	alias       the aliases/misspellings for this work
+//	arid        artist id
+//	artist      artist name, an artist in the context of a work is an artist-work relation such as composer or lyricist
+//	comment     disambiguation comment
+//	iswc        ISWC of work
+//	lang        Lyrics language of work
+//	tag         folksonomy tag
+//	type        work type
+//	wid         work id
+//	work        name of work
+//	workaccent  name of the work with any accent characters retained
+//
+// With no fields specified searchTerm searches the work and alias fields. For
+// more information visit
+// https://musicbrainz.org/doc/Development/XML_Web_Service/Version_2/Search#Work
 func (c *WS2Client) SearchWork(searchTerm string, limit, offset int) (*WorkSearchResponse, error) {
-	//TODO implement
-	return nil, nil
+
+	result, err := Search[*Work](context.Background(), c, searchTerm, limit, offset)
+
+	return &WorkSearchResponse{
+		WS2ListResponse: result.WS2ListResponse,
+		Works:           Entities(result.Results),
+		Scores:          scoreMap(result.Results),
+		Results:         result.Results,
+	}, err
 }
 
+// WorkSearchResponse is the response type returned by the SearchWork method.
 type WorkSearchResponse struct {
-	//TODO implement
+	WS2ListResponse
+	Works   []*Work
+	Scores  ScoreMap
+	Results []Scored[*Work]
+}
+
+// ResultsWithScore returns a slice of Works with a min score.
+func (r *WorkSearchResponse) ResultsWithScore(score int) []*Work {
+	return Entities(FilterByScore(r.Results, score))
 }